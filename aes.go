@@ -0,0 +1,110 @@
+package moneroutil
+
+import (
+	"encoding/binary"
+)
+
+// Software AES rounds, as used by CryptoNight and RandomX. Only single
+// rounds and the key schedule are needed, which crypto/aes does not expose.
+
+var aesSbox, aesInvSbox [256]byte
+
+func init() {
+	// the S-box maps x to the affine transform of its inverse in GF(2^8),
+	// found by walking the powers of the generator 3
+	var exp, log [256]byte
+	x := byte(1)
+	for i := 0; i < 255; i++ {
+		exp[i] = x
+		log[x] = byte(i)
+		x ^= aesXtime(x)
+	}
+	for i := 0; i < 256; i++ {
+		var inv byte
+		if i != 0 {
+			inv = exp[(255-int(log[i]))%255]
+		}
+		s := inv
+		for shift := uint(1); shift < 5; shift++ {
+			s ^= inv<<shift | inv>>(8-shift)
+		}
+		aesSbox[i] = s ^ 0x63
+		aesInvSbox[aesSbox[i]] = byte(i)
+	}
+}
+
+// aesXtime multiplies by x in GF(2^8)
+func aesXtime(b byte) byte {
+	if b&0x80 != 0 {
+		return b<<1 ^ 0x1b
+	}
+	return b << 1
+}
+
+func aesSubWord(w uint32) uint32 {
+	return uint32(aesSbox[w>>24])<<24 | uint32(aesSbox[w>>16&0xff])<<16 | uint32(aesSbox[w>>8&0xff])<<8 | uint32(aesSbox[w&0xff])
+}
+
+// aesExpandKey256 returns the first rounds round keys of the AES-256 key
+// schedule of key
+func aesExpandKey256(key []byte, rounds int) (result [][16]byte) {
+	words := make([]uint32, 4*rounds)
+	for i := 0; i < 8 && i < len(words); i++ {
+		words[i] = binary.BigEndian.Uint32(key[4*i:])
+	}
+	rcon := uint32(1)
+	for i := 8; i < len(words); i++ {
+		t := words[i-1]
+		if i%8 == 0 {
+			t = aesSubWord(t<<8|t>>24) ^ rcon<<24
+			rcon = uint32(aesXtime(byte(rcon)))
+		} else if i%8 == 4 {
+			t = aesSubWord(t)
+		}
+		words[i] = words[i-8] ^ t
+	}
+	result = make([][16]byte, rounds)
+	for i := range result {
+		for j := 0; j < 4; j++ {
+			binary.BigEndian.PutUint32(result[i][4*j:], words[4*i+j])
+		}
+	}
+	return
+}
+
+// aesRound performs SubBytes, ShiftRows, MixColumns and AddRoundKey on a
+// block in place, like the AESENC instruction
+func aesRound(block []byte, roundKey []byte) {
+	var t [16]byte
+	for c := 0; c < 4; c++ {
+		for r := 0; r < 4; r++ {
+			t[4*c+r] = aesSbox[block[4*((c+r)%4)+r]]
+		}
+	}
+	for c := 0; c < 4; c++ {
+		a0, a1, a2, a3 := t[4*c], t[4*c+1], t[4*c+2], t[4*c+3]
+		all := a0 ^ a1 ^ a2 ^ a3
+		block[4*c] = a0 ^ all ^ aesXtime(a0^a1) ^ roundKey[4*c]
+		block[4*c+1] = a1 ^ all ^ aesXtime(a1^a2) ^ roundKey[4*c+1]
+		block[4*c+2] = a2 ^ all ^ aesXtime(a2^a3) ^ roundKey[4*c+2]
+		block[4*c+3] = a3 ^ all ^ aesXtime(a3^a0) ^ roundKey[4*c+3]
+	}
+}
+
+// aesDecRound performs InvShiftRows, InvSubBytes, InvMixColumns and
+// AddRoundKey on a block in place, like the AESDEC instruction
+func aesDecRound(block []byte, roundKey []byte) {
+	var t [16]byte
+	for c := 0; c < 4; c++ {
+		for r := 0; r < 4; r++ {
+			t[4*c+r] = aesInvSbox[block[4*((c-r+4)%4)+r]]
+		}
+	}
+	for c := 0; c < 4; c++ {
+		a0, a1, a2, a3 := t[4*c], t[4*c+1], t[4*c+2], t[4*c+3]
+		block[4*c] = gfMul(a0, 14) ^ gfMul(a1, 11) ^ gfMul(a2, 13) ^ gfMul(a3, 9) ^ roundKey[4*c]
+		block[4*c+1] = gfMul(a0, 9) ^ gfMul(a1, 14) ^ gfMul(a2, 11) ^ gfMul(a3, 13) ^ roundKey[4*c+1]
+		block[4*c+2] = gfMul(a0, 13) ^ gfMul(a1, 9) ^ gfMul(a2, 14) ^ gfMul(a3, 11) ^ roundKey[4*c+2]
+		block[4*c+3] = gfMul(a0, 11) ^ gfMul(a1, 13) ^ gfMul(a2, 9) ^ gfMul(a3, 14) ^ roundKey[4*c+3]
+	}
+}
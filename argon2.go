@@ -0,0 +1,158 @@
+package moneroutil
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// Argon2d with a single lane and no output, which RandomX uses to fill its
+// cache
+
+const (
+	argon2Version     = 0x13
+	argon2TypeD       = 0
+	argon2BlockWords  = 128
+	argon2SyncPoints  = 4
+	argon2InitBlocks  = 2
+	argon2BlockLength = 8 * argon2BlockWords
+)
+
+// blamka is the multiplication hardened addition of Argon2
+func blamka(x, y uint64) uint64 {
+	return x + y + 2*uint64(uint32(x))*uint64(uint32(y))
+}
+
+func argon2G(v *[argon2BlockWords]uint64, a, b, c, d int) {
+	v[a] = blamka(v[a], v[b])
+	v[d] = bits.RotateLeft64(v[d]^v[a], -32)
+	v[c] = blamka(v[c], v[d])
+	v[b] = bits.RotateLeft64(v[b]^v[c], -24)
+	v[a] = blamka(v[a], v[b])
+	v[d] = bits.RotateLeft64(v[d]^v[a], -16)
+	v[c] = blamka(v[c], v[d])
+	v[b] = bits.RotateLeft64(v[b]^v[c], -63)
+}
+
+// argon2Round applies the BLAKE2b round without message to the 16 words at
+// the given indices
+func argon2Round(v *[argon2BlockWords]uint64, i *[16]int) {
+	argon2G(v, i[0], i[4], i[8], i[12])
+	argon2G(v, i[1], i[5], i[9], i[13])
+	argon2G(v, i[2], i[6], i[10], i[14])
+	argon2G(v, i[3], i[7], i[11], i[15])
+	argon2G(v, i[0], i[5], i[10], i[15])
+	argon2G(v, i[1], i[6], i[11], i[12])
+	argon2G(v, i[2], i[7], i[8], i[13])
+	argon2G(v, i[3], i[4], i[9], i[14])
+}
+
+// argon2RoundIndices are the words of the 8 row rounds and the 8 column
+// rounds of the compression function
+var argon2RoundIndices [16][16]int
+
+func init() {
+	for i := 0; i < 8; i++ {
+		for j := 0; j < 16; j++ {
+			argon2RoundIndices[i][j] = 16*i + j
+			argon2RoundIndices[8+i][j] = 2*i + 16*(j/2) + j%2
+		}
+	}
+}
+
+// argon2FillBlock computes next from prev and ref, xoring in the old next
+// block after the first pass
+func argon2FillBlock(prev, ref, next []uint64, withXor bool) {
+	var r, tmp [argon2BlockWords]uint64
+	for i := range r {
+		r[i] = prev[i] ^ ref[i]
+	}
+	tmp = r
+	if withXor {
+		for i := range tmp {
+			tmp[i] ^= next[i]
+		}
+	}
+	for i := range argon2RoundIndices {
+		argon2Round(&r, &argon2RoundIndices[i])
+	}
+	for i := range r {
+		next[i] = tmp[i] ^ r[i]
+	}
+}
+
+// argon2d returns the memory of memoryKiB blocks after passes passes
+func argon2d(password, salt []byte, passes, memoryKiB uint32) (memory []uint64) {
+	var h0Input []byte
+	putUint32 := func(x uint32) {
+		var b [4]byte
+		binary.LittleEndian.PutUint32(b[:], x)
+		h0Input = append(h0Input, b[:]...)
+	}
+	putUint32(1) // lanes
+	putUint32(0) // tag length
+	putUint32(memoryKiB)
+	putUint32(passes)
+	putUint32(argon2Version)
+	putUint32(argon2TypeD)
+	putUint32(uint32(len(password)))
+	h0Input = append(h0Input, password...)
+	putUint32(uint32(len(salt)))
+	h0Input = append(h0Input, salt...)
+	putUint32(0) // secret
+	putUint32(0) // associated data
+	h0 := blake2b(h0Input, 64)
+
+	memory = make([]uint64, uint64(memoryKiB)*argon2BlockWords)
+	block := func(i uint64) []uint64 {
+		return memory[i*argon2BlockWords : (i+1)*argon2BlockWords]
+	}
+	for i := uint32(0); i < argon2InitBlocks; i++ {
+		seed := make([]byte, len(h0)+8)
+		copy(seed, h0)
+		binary.LittleEndian.PutUint32(seed[len(h0):], i)
+		initial := blake2bLong(seed, argon2BlockLength)
+		words := block(uint64(i))
+		for j := range words {
+			words[j] = binary.LittleEndian.Uint64(initial[8*j:])
+		}
+	}
+
+	laneLength := uint64(memoryKiB)
+	segmentLength := laneLength / argon2SyncPoints
+	for pass := uint32(0); pass < passes; pass++ {
+		for slice := uint64(0); slice < argon2SyncPoints; slice++ {
+			index := uint64(0)
+			if pass == 0 && slice == 0 {
+				index = argon2InitBlocks
+			}
+			for ; index < segmentLength; index++ {
+				current := slice*segmentLength + index
+				prev := current - 1
+				if current == 0 {
+					prev = laneLength - 1
+				}
+				// the reference block is picked from the first word of the
+				// previous block, biased towards recent blocks
+				var areaSize uint64
+				switch {
+				case pass == 0 && slice == 0:
+					areaSize = index - 1
+				case pass == 0:
+					areaSize = slice*segmentLength + index - 1
+				default:
+					areaSize = laneLength - segmentLength + index - 1
+				}
+				j1 := uint64(uint32(memory[prev*argon2BlockWords]))
+				relative := j1 * j1 >> 32
+				relative = areaSize - 1 - areaSize*relative>>32
+				start := uint64(0)
+				if pass != 0 && slice != argon2SyncPoints-1 {
+					start = (slice + 1) * segmentLength
+				}
+				ref := (start + relative) % laneLength
+				argon2FillBlock(block(prev), block(ref), block(current), pass != 0)
+			}
+		}
+	}
+	return
+}
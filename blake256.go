@@ -0,0 +1,102 @@
+package moneroutil
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// BLAKE-256 with 14 rounds, one of the CryptoNight finalizers
+
+var blake256IV = [8]uint32{
+	0x6a09e667, 0xbb67ae85, 0x3c6ef372, 0xa54ff53a, 0x510e527f, 0x9b05688c, 0x1f83d9ab, 0x5be0cd19,
+}
+
+var blake256Constants = [16]uint32{
+	0x243f6a88, 0x85a308d3, 0x13198a2e, 0x03707344, 0xa4093822, 0x299f31d0, 0x082efa98, 0xec4e6c89,
+	0x452821e6, 0x38d01377, 0xbe5466cf, 0x34e90c6c, 0xc0ac29b7, 0xc97c50dd, 0x3f84d5b5, 0xb5470917,
+}
+
+var blakeSigma = [10][16]uint8{
+	{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+	{14, 10, 4, 8, 9, 15, 13, 6, 1, 12, 0, 2, 11, 7, 5, 3},
+	{11, 8, 12, 0, 5, 2, 15, 13, 10, 14, 3, 6, 7, 1, 9, 4},
+	{7, 9, 3, 1, 13, 12, 11, 14, 2, 6, 5, 10, 4, 0, 15, 8},
+	{9, 0, 5, 7, 2, 4, 10, 15, 14, 1, 11, 12, 6, 8, 3, 13},
+	{2, 12, 6, 10, 0, 11, 8, 3, 4, 13, 7, 5, 15, 14, 1, 9},
+	{12, 5, 1, 15, 14, 13, 4, 10, 0, 7, 6, 3, 9, 2, 8, 11},
+	{13, 11, 7, 14, 12, 1, 3, 9, 5, 0, 15, 4, 8, 6, 2, 10},
+	{6, 15, 14, 9, 11, 3, 0, 8, 12, 2, 13, 7, 1, 4, 10, 5},
+	{10, 2, 8, 4, 7, 6, 1, 5, 15, 11, 9, 14, 3, 12, 13, 0},
+}
+
+// blake256Compress hashes a 64 byte block into h. counter is the number of
+// message bits up to the end of the block, or zero for a block holding
+// only padding.
+func blake256Compress(h *[8]uint32, block []byte, counter uint64) {
+	var m [16]uint32
+	for i := range m {
+		m[i] = binary.BigEndian.Uint32(block[4*i:])
+	}
+	var v [16]uint32
+	copy(v[:8], h[:])
+	copy(v[8:], blake256Constants[:8])
+	v[12] ^= uint32(counter)
+	v[13] ^= uint32(counter)
+	v[14] ^= uint32(counter >> 32)
+	v[15] ^= uint32(counter >> 32)
+	g := func(r, i, a, b, c, d int) {
+		s := blakeSigma[r%10]
+		v[a] += v[b] + (m[s[2*i]] ^ blake256Constants[s[2*i+1]])
+		v[d] = bits.RotateLeft32(v[d]^v[a], -16)
+		v[c] += v[d]
+		v[b] = bits.RotateLeft32(v[b]^v[c], -12)
+		v[a] += v[b] + (m[s[2*i+1]] ^ blake256Constants[s[2*i]])
+		v[d] = bits.RotateLeft32(v[d]^v[a], -8)
+		v[c] += v[d]
+		v[b] = bits.RotateLeft32(v[b]^v[c], -7)
+	}
+	for r := 0; r < 14; r++ {
+		g(r, 0, 0, 4, 8, 12)
+		g(r, 1, 1, 5, 9, 13)
+		g(r, 2, 2, 6, 10, 14)
+		g(r, 3, 3, 7, 11, 15)
+		g(r, 4, 0, 5, 10, 15)
+		g(r, 5, 1, 6, 11, 12)
+		g(r, 6, 2, 7, 8, 13)
+		g(r, 7, 3, 4, 9, 14)
+	}
+	for i := range h {
+		h[i] ^= v[i] ^ v[i+8]
+	}
+}
+
+func blake256(data []byte) (result Hash) {
+	h := blake256IV
+	bitLength := uint64(len(data)) * 8
+	var hashed uint64
+	for ; len(data) >= 64; data = data[64:] {
+		hashed += 512
+		blake256Compress(&h, data[:64], hashed)
+	}
+	// the padding is a one bit, zeros, a one bit and the 64 bit length
+	padded := make([]byte, 64, 128)
+	copy(padded, data)
+	padded[len(data)] = 0x80
+	if len(data) >= 56 {
+		padded = padded[:128]
+	}
+	padded[len(padded)-9] |= 1
+	binary.BigEndian.PutUint64(padded[len(padded)-8:], bitLength)
+	if len(padded) == 128 {
+		blake256Compress(&h, padded[:64], bitLength)
+		blake256Compress(&h, padded[64:], 0)
+	} else if len(data) == 0 {
+		blake256Compress(&h, padded, 0)
+	} else {
+		blake256Compress(&h, padded, bitLength)
+	}
+	for i, w := range h {
+		binary.BigEndian.PutUint32(result[4*i:], w)
+	}
+	return
+}
@@ -0,0 +1,95 @@
+package moneroutil
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// BLAKE2b without a key, as used by RandomX and Argon2
+
+var blake2bIV = [8]uint64{
+	0x6a09e667f3bcc908, 0xbb67ae8584caa73b, 0x3c6ef372fe94f82b, 0xa54ff53a5f1d36f1,
+	0x510e527fade682d1, 0x9b05688c2b3e6c1f, 0x1f83d9abfb41bd6b, 0x5be0cd19137e2179,
+}
+
+// blake2bCompress hashes a 128 byte block into h. counter is the number
+// of bytes hashed up to the end of the block.
+func blake2bCompress(h *[8]uint64, block []byte, counter uint64, final bool) {
+	var m [16]uint64
+	for i := range m {
+		m[i] = binary.LittleEndian.Uint64(block[8*i:])
+	}
+	var v [16]uint64
+	copy(v[:8], h[:])
+	copy(v[8:], blake2bIV[:])
+	v[12] ^= counter
+	if final {
+		v[14] = ^v[14]
+	}
+	g := func(s *[16]uint8, i, a, b, c, d int) {
+		v[a] += v[b] + m[s[2*i]]
+		v[d] = bits.RotateLeft64(v[d]^v[a], -32)
+		v[c] += v[d]
+		v[b] = bits.RotateLeft64(v[b]^v[c], -24)
+		v[a] += v[b] + m[s[2*i+1]]
+		v[d] = bits.RotateLeft64(v[d]^v[a], -16)
+		v[c] += v[d]
+		v[b] = bits.RotateLeft64(v[b]^v[c], -63)
+	}
+	for r := 0; r < 12; r++ {
+		s := &blakeSigma[r%10]
+		g(s, 0, 0, 4, 8, 12)
+		g(s, 1, 1, 5, 9, 13)
+		g(s, 2, 2, 6, 10, 14)
+		g(s, 3, 3, 7, 11, 15)
+		g(s, 4, 0, 5, 10, 15)
+		g(s, 5, 1, 6, 11, 12)
+		g(s, 6, 2, 7, 8, 13)
+		g(s, 7, 3, 4, 9, 14)
+	}
+	for i := range h {
+		h[i] ^= v[i] ^ v[i+8]
+	}
+}
+
+// blake2b returns the size byte BLAKE2b digest of data, for sizes up to 64
+func blake2b(data []byte, size int) (result []byte) {
+	h := blake2bIV
+	h[0] ^= 0x01010000 ^ uint64(size)
+	var counter uint64
+	for ; len(data) > 128; data = data[128:] {
+		counter += 128
+		blake2bCompress(&h, data[:128], counter, false)
+	}
+	block := make([]byte, 128)
+	copy(block, data)
+	counter += uint64(len(data))
+	blake2bCompress(&h, block, counter, true)
+	out := make([]byte, 64)
+	for i, w := range h {
+		binary.LittleEndian.PutUint64(out[8*i:], w)
+	}
+	result = out[:size]
+	return
+}
+
+// blake2bLong is the variable length hash H' of Argon2
+func blake2bLong(data []byte, size int) (result []byte) {
+	prefixed := make([]byte, 4+len(data))
+	binary.LittleEndian.PutUint32(prefixed, uint32(size))
+	copy(prefixed[4:], data)
+	if size <= 64 {
+		result = blake2b(prefixed, size)
+		return
+	}
+	result = make([]byte, 0, size)
+	v := blake2b(prefixed, 64)
+	for size-len(result) > 64 {
+		result = append(result, v[:32]...)
+		if size-len(result) > 64 {
+			v = blake2b(v, 64)
+		}
+	}
+	result = append(result, blake2b(v, size-len(result))...)
+	return
+}
@@ -1,5 +1,18 @@
 package moneroutil
 
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Block 202612 was accepted with a hash computed by a buggy tree hash.
+// Its blob hashes to blockHash202612Blob and its id stays blockHash202612.
+var (
+	blockHash202612Blob = Hash{0x3a, 0x8a, 0x2b, 0x3a, 0x29, 0xb5, 0x0f, 0xc8, 0x6f, 0xf7, 0x3d, 0xd0, 0x87, 0xea, 0x43, 0xc6, 0xf0, 0xd6, 0xb8, 0xf9, 0x36, 0xc8, 0x49, 0x19, 0x4d, 0x5c, 0x84, 0xc7, 0x37, 0x90, 0x39, 0x66}
+	blockHash202612     = Hash{0xbb, 0xd6, 0x04, 0xd2, 0xba, 0x11, 0xba, 0x27, 0x93, 0x5e, 0x00, 0x6e, 0xd3, 0x9c, 0x9b, 0xfd, 0xd9, 0x9b, 0x76, 0xbf, 0x4a, 0x50, 0x65, 0x4b, 0xc1, 0xe1, 0xe6, 0x12, 0x17, 0x96, 0x26, 0x98}
+)
+
 type BlockHeader struct {
 	majorVersion uint8
 	minorVersion uint8
@@ -13,3 +26,146 @@ type Block struct {
 	MinerTx  Transaction
 	TxHashes []Hash
 }
+
+func (b *BlockHeader) MajorVersion() uint8 {
+	return b.majorVersion
+}
+
+// MinorVersion is the hard fork version the miner votes for
+func (b *BlockHeader) MinorVersion() uint8 {
+	return b.minorVersion
+}
+
+func (b *BlockHeader) TimeStamp() uint64 {
+	return b.timeStamp
+}
+
+func (b *BlockHeader) PreviousHash() Hash {
+	return b.previousHash
+}
+
+func (b *BlockHeader) Nonce() uint32 {
+	return b.nonce
+}
+
+func (b *BlockHeader) Serialize() (result []byte) {
+	result = append(Uint64ToBytes(uint64(b.majorVersion)), Uint64ToBytes(uint64(b.minorVersion))...)
+	result = append(result, Uint64ToBytes(b.timeStamp)...)
+	result = append(result, b.previousHash[:]...)
+	nonce := make([]byte, 4)
+	binary.LittleEndian.PutUint32(nonce, b.nonce)
+	result = append(result, nonce...)
+	return
+}
+
+func (b *Block) Serialize() (result []byte) {
+	result = append(b.BlockHeader.Serialize(), b.MinerTx.Serialize()...)
+	result = append(result, Uint64ToBytes(uint64(len(b.TxHashes)))...)
+	for _, txHash := range b.TxHashes {
+		result = append(result, txHash[:]...)
+	}
+	return
+}
+
+// TxTreeHash is the merkle root of the miner transaction hash followed by
+// the other transaction hashes
+func (b *Block) TxTreeHash() (result Hash) {
+	result = TreeHash(b.txTreeLeaves())
+	return
+}
+
+func (b *Block) txTreeLeaves() []Hash {
+	return append([]Hash{b.MinerTx.GetHash()}, b.TxHashes...)
+}
+
+// TxBranch proves that the transaction with txHash is in the block. The
+// branch and path lead to TxTreeHash, see IsBranchInTree.
+func (b *Block) TxBranch(txHash Hash) (branch []Hash, path uint32, err error) {
+	branch, path, err = TreeBranch(b.txTreeLeaves(), txHash)
+	return
+}
+
+// HashingBlob is the header, the transaction tree hash and the number of
+// transactions including the miner transaction. It is what the block hash
+// and the proof of work are computed from.
+func (b *Block) HashingBlob() (result []byte) {
+	treeHash := b.TxTreeHash()
+	result = append(b.BlockHeader.Serialize(), treeHash[:]...)
+	result = append(result, Uint64ToBytes(uint64(len(b.TxHashes)+1))...)
+	return
+}
+
+// Hash is the block id: the hash of the length prefixed hashing blob
+func (b *Block) Hash() (result Hash) {
+	// as monerod, only look for block 202612 at its height
+	if b.MinerTx.IsCoinbase() && b.MinerTx.vin[0].(*TxInGen).height == 202612 && Keccak256(b.Serialize()) == blockHash202612Blob {
+		result = blockHash202612
+		return
+	}
+	blob := b.HashingBlob()
+	result = Keccak256(Uint64ToBytes(uint64(len(blob))), blob)
+	return
+}
+
+// parseVersion reads a varint that has to fit in a byte
+func parseVersion(buf io.Reader) (result uint8, err error) {
+	version, err := ReadVarInt(buf)
+	if err != nil {
+		return
+	}
+	if version > 0xff {
+		err = fmt.Errorf("Bad block version %d", version)
+		return
+	}
+	result = uint8(version)
+	return
+}
+
+func ParseBlockHeader(buf io.Reader) (result *BlockHeader, err error) {
+	b := new(BlockHeader)
+	if b.majorVersion, err = parseVersion(buf); err != nil {
+		return
+	}
+	if b.minorVersion, err = parseVersion(buf); err != nil {
+		return
+	}
+	if b.timeStamp, err = ReadVarInt(buf); err != nil {
+		return
+	}
+	if b.previousHash, err = parseHash(buf); err != nil {
+		return
+	}
+	nonce := make([]byte, 4)
+	if _, err = io.ReadFull(buf, nonce); err != nil {
+		return
+	}
+	b.nonce = binary.LittleEndian.Uint32(nonce)
+	result = b
+	return
+}
+
+func ParseBlock(buf io.Reader) (result *Block, err error) {
+	header, err := ParseBlockHeader(buf)
+	if err != nil {
+		return
+	}
+	b := &Block{BlockHeader: *header}
+	minerTx, err := ParseTransaction(buf)
+	if err != nil {
+		return
+	}
+	b.MinerTx = *minerTx
+	numTxHashes, err := readCount(buf, HashLength)
+	if err != nil {
+		return
+	}
+	for i := uint64(0); i < numTxHashes; i++ {
+		var txHash Hash
+		if txHash, err = parseHash(buf); err != nil {
+			return
+		}
+		b.TxHashes = append(b.TxHashes, txHash)
+	}
+	result = b
+	return
+}
@@ -0,0 +1,167 @@
+package moneroutil
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestBlock(t *testing.T) {
+	tests := []struct {
+		name           string
+		blockHex       string
+		hashHex        string
+		hashingBlobHex string
+		majorVersion   uint8
+		nonce          uint32
+		numTxHashes    int
+	}{
+		{
+			name:           "genesis",
+			blockHex:       "010000000000000000000000000000000000000000000000000000000000000000000010270000013c01ff0001ffffffffffff03029b2e4c0281c0b02e7c53291a94d1d0cbff8883f8024f5142ee494ffbbd08807121017767aafcde9be00dcfd098715ebcf7f410daebc582fda69d24a28e9d0bc890d100",
+			hashHex:        "418015bb9ae982a1975da7d79277c2705727a56894ba0fb246adaabb1f4632e3",
+			hashingBlobHex: "010000000000000000000000000000000000000000000000000000000000000000000010270000c88ce9783b4f11190d7b9c17a69c1c52200f9faaee8e98dd07e681117517713901",
+			majorVersion:   1,
+			nonce:          10000,
+		},
+	}
+	for _, test := range tests {
+		serialized, _ := hex.DecodeString(test.blockHex)
+		block, err := ParseBlock(bytes.NewReader(serialized))
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+			continue
+		}
+		if block.MajorVersion() != test.majorVersion {
+			t.Errorf("%s: major version: want %d, got %d", test.name, test.majorVersion, block.MajorVersion())
+		}
+		if block.Nonce() != test.nonce {
+			t.Errorf("%s: nonce: want %d, got %d", test.name, test.nonce, block.Nonce())
+		}
+		if len(block.TxHashes) != test.numTxHashes {
+			t.Errorf("%s: tx hashes: want %d, got %d", test.name, test.numTxHashes, len(block.TxHashes))
+		}
+		wantHash := HexToHash(test.hashHex)
+		if gotHash := block.Hash(); wantHash != gotHash {
+			t.Errorf("%s: want %x, got %x", test.name, wantHash, gotHash)
+		}
+		wantBlob, _ := hex.DecodeString(test.hashingBlobHex)
+		if gotBlob := block.HashingBlob(); bytes.Compare(wantBlob, gotBlob) != 0 {
+			t.Errorf("%s: hashing blob: want %x, got %x", test.name, wantBlob, gotBlob)
+		}
+		if got := block.Serialize(); bytes.Compare(serialized, got) != 0 {
+			t.Errorf("%s: want %x, got %x", test.name, serialized, got)
+		}
+	}
+}
+
+func TestBlockTxHashes(t *testing.T) {
+	genesis, _ := hex.DecodeString("010000000000000000000000000000000000000000000000000000000000000000000010270000013c01ff0001ffffffffffff03029b2e4c0281c0b02e7c53291a94d1d0cbff8883f8024f5142ee494ffbbd08807121017767aafcde9be00dcfd098715ebcf7f410daebc582fda69d24a28e9d0bc890d100")
+	block, err := ParseBlock(bytes.NewReader(genesis))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	block.TxHashes = []Hash{Keccak256([]byte("a")), Keccak256([]byte("b"))}
+	serialized := block.Serialize()
+	parsed, err := ParseBlock(bytes.NewReader(serialized))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if got := parsed.Serialize(); bytes.Compare(serialized, got) != 0 {
+		t.Errorf("want %x, got %x", serialized, got)
+	}
+	minerTxHash := block.MinerTx.GetHash()
+	inner := Keccak256(block.TxHashes[0][:], block.TxHashes[1][:])
+	wantRoot := Keccak256(minerTxHash[:], inner[:])
+	if gotRoot := parsed.TxTreeHash(); wantRoot != gotRoot {
+		t.Errorf("tree hash: want %x, got %x", wantRoot, gotRoot)
+	}
+	branch, path, err := parsed.TxBranch(block.TxHashes[1])
+	if err != nil {
+		t.Errorf("branch: %s", err)
+	} else if !IsBranchInTree(block.TxHashes[1], wantRoot, branch, path) {
+		t.Errorf("branch: want tx in tree")
+	}
+	blob := parsed.HashingBlob()
+	if blob[len(blob)-1] != 3 {
+		t.Errorf("want 3 transactions in hashing blob, got %d", blob[len(blob)-1])
+	}
+
+	if _, err = ParseBlock(bytes.NewReader([]byte{0x80, 0x02})); err == nil {
+		t.Errorf("want error for major version 256")
+	}
+}
+
+func TestBlockHash202612(t *testing.T) {
+	genesis, _ := hex.DecodeString("010000000000000000000000000000000000000000000000000000000000000000000010270000013c01ff0001ffffffffffff03029b2e4c0281c0b02e7c53291a94d1d0cbff8883f8024f5142ee494ffbbd08807121017767aafcde9be00dcfd098715ebcf7f410daebc582fda69d24a28e9d0bc890d100")
+	block, err := ParseBlock(bytes.NewReader(genesis))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// another block at height 202612 keeps the usual hash
+	block.MinerTx.vin[0].(*TxInGen).height = 202612
+	blob := block.HashingBlob()
+	want := Keccak256(Uint64ToBytes(uint64(len(blob))), blob)
+	if got := block.Hash(); want != got {
+		t.Errorf("want %x, got %x", want, got)
+	}
+
+	// the real blob of block 202612 is not at hand, so stand this one in
+	// for it
+	defer func(blobHash Hash) { blockHash202612Blob = blobHash }(blockHash202612Blob)
+	blockHash202612Blob = Keccak256(block.Serialize())
+	if got := block.Hash(); blockHash202612 != got {
+		t.Errorf("block 202612: want %x, got %x", blockHash202612, got)
+	}
+	// a matching blob is only looked for at height 202612
+	block.MinerTx.vin[0].(*TxInGen).height = 202613
+	blockHash202612Blob = Keccak256(block.Serialize())
+	if got := block.Hash(); blockHash202612 == got {
+		t.Errorf("want the usual hash at height 202613")
+	}
+}
+
+func TestBlockRctCoinbase(t *testing.T) {
+	wallet := newTestWallet(newTestReader("coinbase wallet"))
+	builder := &CoinbaseBuilder{
+		Height:    3000000,
+		Reward:    600000000000,
+		Address:   wallet.address,
+		HFVersion: 16,
+	}
+	previousHash := HexToHash("418015bb9ae982a1975da7d79277c2705727a56894ba0fb246adaabb1f4632e3")
+	txHashes := []Hash{Keccak256([]byte("a")), Keccak256([]byte("b"))}
+	block, serialized, _, err := builder.BlockTemplate(newTestReader("rct coinbase"), 1700000000, previousHash, txHashes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := ParseBlock(bytes.NewReader(serialized))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !parsed.MinerTx.vout[0].tagged || parsed.MinerTx.rctSignature.sigType != RCTTypeNull {
+		t.Errorf("want a tagged output and a null RingCT signature")
+	}
+	// a version 2 coinbase hashes its prefix, its one byte RingCT base and
+	// the null hash for its missing prunable part
+	prefixHash := parsed.MinerTx.PrefixHash()
+	baseHash := Keccak256([]byte{RCTTypeNull})
+	minerTxHash := Keccak256(prefixHash[:], baseHash[:], make([]byte, HashLength))
+	if got := parsed.MinerTx.GetHash(); minerTxHash != got {
+		t.Errorf("miner tx hash: want %x, got %x", minerTxHash, got)
+	}
+	inner := Keccak256(txHashes[0][:], txHashes[1][:])
+	wantRoot := Keccak256(minerTxHash[:], inner[:])
+	if got := parsed.TxTreeHash(); wantRoot != got {
+		t.Errorf("tree hash: want %x, got %x", wantRoot, got)
+	}
+	wantBlob := append(block.BlockHeader.Serialize(), wantRoot[:]...)
+	wantBlob = append(wantBlob, 3)
+	if got := parsed.HashingBlob(); !bytes.Equal(wantBlob, got) {
+		t.Errorf("hashing blob: want %x, got %x", wantBlob, got)
+	}
+	wantHash := Keccak256(Uint64ToBytes(uint64(len(wantBlob))), wantBlob)
+	if got := parsed.Hash(); wantHash != got {
+		t.Errorf("block id: want %x, got %x", wantHash, got)
+	}
+}
@@ -0,0 +1,127 @@
+package moneroutil
+
+import (
+	"io"
+)
+
+// Bulletproof is the aggregated range proof of RingCT types Bulletproof,
+// Bulletproof2 and CLSAG, replaced by BulletproofPlus. As with
+// BulletproofPlus, V is not serialized.
+type Bulletproof struct {
+	v      []Key
+	a      Key
+	s      Key
+	t1     Key
+	t2     Key
+	taux   Key
+	mu     Key
+	l      []Key
+	r      []Key
+	innerA Key
+	innerB Key
+	t      Key
+}
+
+func (b *Bulletproof) clone() (result *Bulletproof) {
+	copied := *b
+	copied.v = append([]Key(nil), b.v...)
+	copied.l = append([]Key(nil), b.l...)
+	copied.r = append([]Key(nil), b.r...)
+	result = &copied
+	return
+}
+
+func (b *Bulletproof) V() (result []Key) {
+	result = append([]Key(nil), b.v...)
+	return
+}
+
+func (b *Bulletproof) A() Key {
+	return b.a
+}
+
+func (b *Bulletproof) S() Key {
+	return b.s
+}
+
+func (b *Bulletproof) T1() Key {
+	return b.t1
+}
+
+func (b *Bulletproof) T2() Key {
+	return b.t2
+}
+
+func (b *Bulletproof) Taux() Key {
+	return b.taux
+}
+
+func (b *Bulletproof) Mu() Key {
+	return b.mu
+}
+
+func (b *Bulletproof) L() (result []Key) {
+	result = append([]Key(nil), b.l...)
+	return
+}
+
+func (b *Bulletproof) R() (result []Key) {
+	result = append([]Key(nil), b.r...)
+	return
+}
+
+// InnerA is the final scalar a of the inner product argument
+func (b *Bulletproof) InnerA() Key {
+	return b.innerA
+}
+
+// InnerB is the final scalar b of the inner product argument
+func (b *Bulletproof) InnerB() Key {
+	return b.innerB
+}
+
+func (b *Bulletproof) T() Key {
+	return b.t
+}
+
+// hashKeys is the part of the proof that goes into the RingCT signature
+// hash: everything serialized, without the vector lengths
+func (b *Bulletproof) hashKeys() (result []byte) {
+	result = serializeKeys([]Key{b.a, b.s, b.t1, b.t2, b.taux, b.mu})
+	result = append(result, serializeKeys(b.l)...)
+	result = append(result, serializeKeys(b.r)...)
+	result = append(result, serializeKeys([]Key{b.innerA, b.innerB, b.t})...)
+	return
+}
+
+func (b *Bulletproof) Serialize() (result []byte) {
+	result = serializeKeys([]Key{b.a, b.s, b.t1, b.t2, b.taux, b.mu})
+	result = append(result, Uint64ToBytes(uint64(len(b.l)))...)
+	result = append(result, serializeKeys(b.l)...)
+	result = append(result, Uint64ToBytes(uint64(len(b.r)))...)
+	result = append(result, serializeKeys(b.r)...)
+	result = append(result, serializeKeys([]Key{b.innerA, b.innerB, b.t})...)
+	return
+}
+
+func ParseBulletproof(buf io.Reader) (result *Bulletproof, err error) {
+	b := new(Bulletproof)
+	for _, k := range []*Key{&b.a, &b.s, &b.t1, &b.t2, &b.taux, &b.mu} {
+		if *k, err = ParseKey(buf); err != nil {
+			return
+		}
+	}
+	if b.l, err = parseKeyVector(buf); err != nil {
+		return
+	}
+	if b.r, err = parseKeyVector(buf); err != nil {
+		return
+	}
+	for _, k := range []*Key{&b.innerA, &b.innerB, &b.t} {
+		if *k, err = ParseKey(buf); err != nil {
+			return
+		}
+	}
+	result = b
+	return
+}
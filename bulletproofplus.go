@@ -0,0 +1,598 @@
+package moneroutil
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+const (
+	bulletproofPlusLogN = 6
+	bulletproofPlusN    = 1 << bulletproofPlusLogN
+	// BulletproofPlusMaxOutputs is the number of amounts a single proof
+	// can cover
+	BulletproofPlusMaxOutputs = 16
+)
+
+// BulletproofPlus is an aggregated range proof that every commitment in V
+// hides an amount below 2^64. V is not serialized: it is the output
+// commitments multiplied by 1/8.
+type BulletproofPlus struct {
+	v  []Key
+	a  Key
+	a1 Key
+	b  Key
+	r1 Key
+	s1 Key
+	d1 Key
+	l  []Key
+	r  []Key
+}
+
+// invEight is 1/8 mod l. Points are stored divided by 8 so that verifiers
+// can multiply by 8 to clear any small order component.
+var invEight = func() (result Key) {
+	ScFromUint64(&result, 8)
+	ScInvert(&result, &result)
+	return
+}()
+
+type bulletproofPlusGenerators struct {
+	gi, hi     []ExtendedGroupElement
+	h          ExtendedGroupElement
+	transcript Key
+}
+
+var (
+	bulletproofPlusGensOnce sync.Once
+	bulletproofPlusGens     bulletproofPlusGenerators
+)
+
+// getBulletproofPlusGens derives the generators Gi and Hi from H, and the
+// initial transcript, the first time they are needed
+func getBulletproofPlusGens() *bulletproofPlusGenerators {
+	bulletproofPlusGensOnce.Do(func() {
+		gens := &bulletproofPlusGens
+		maxMN := bulletproofPlusN * BulletproofPlusMaxOutputs
+		gens.gi = make([]ExtendedGroupElement, maxMN)
+		gens.hi = make([]ExtendedGroupElement, maxMN)
+		for i := 0; i < maxMN; i++ {
+			hashHi := Keccak256(H[:], []byte("bulletproof_plus"), Uint64ToBytes(uint64(2*i)))
+			hashGi := Keccak256(H[:], []byte("bulletproof_plus"), Uint64ToBytes(uint64(2*i+1)))
+			gens.hi[i] = *HashToPoint(hashHi[:])
+			gens.gi[i] = *HashToPoint(hashGi[:])
+		}
+		gens.h.FromBytes(&H)
+		transcriptHash := Keccak256([]byte("bulletproof_plus_transcript"))
+		HashToPoint(transcriptHash[:]).ToBytes(&gens.transcript)
+	})
+	return &bulletproofPlusGens
+}
+
+func (b *BulletproofPlus) clone() (result *BulletproofPlus) {
+	copied := *b
+	copied.v = append([]Key(nil), b.v...)
+	copied.l = append([]Key(nil), b.l...)
+	copied.r = append([]Key(nil), b.r...)
+	result = &copied
+	return
+}
+
+func (b *BulletproofPlus) V() (result []Key) {
+	result = append([]Key(nil), b.v...)
+	return
+}
+
+func (b *BulletproofPlus) A() Key {
+	return b.a
+}
+
+func (b *BulletproofPlus) A1() Key {
+	return b.a1
+}
+
+func (b *BulletproofPlus) B() Key {
+	return b.b
+}
+
+func (b *BulletproofPlus) R1() Key {
+	return b.r1
+}
+
+func (b *BulletproofPlus) S1() Key {
+	return b.s1
+}
+
+func (b *BulletproofPlus) D1() Key {
+	return b.d1
+}
+
+func (b *BulletproofPlus) L() (result []Key) {
+	result = append([]Key(nil), b.l...)
+	return
+}
+
+func (b *BulletproofPlus) R() (result []Key) {
+	result = append([]Key(nil), b.r...)
+	return
+}
+
+// hashKeys is the part of the proof that goes into the RingCT signature
+// hash: everything serialized, without the vector lengths
+func (b *BulletproofPlus) hashKeys() (result []byte) {
+	result = append(result, b.a[:]...)
+	result = append(result, b.a1[:]...)
+	result = append(result, b.b[:]...)
+	result = append(result, b.r1[:]...)
+	result = append(result, b.s1[:]...)
+	result = append(result, b.d1[:]...)
+	result = append(result, serializeKeys(b.l)...)
+	result = append(result, serializeKeys(b.r)...)
+	return
+}
+
+func (b *BulletproofPlus) Serialize() (result []byte) {
+	result = append(result, b.a[:]...)
+	result = append(result, b.a1[:]...)
+	result = append(result, b.b[:]...)
+	result = append(result, b.r1[:]...)
+	result = append(result, b.s1[:]...)
+	result = append(result, b.d1[:]...)
+	result = append(result, Uint64ToBytes(uint64(len(b.l)))...)
+	result = append(result, serializeKeys(b.l)...)
+	result = append(result, Uint64ToBytes(uint64(len(b.r)))...)
+	result = append(result, serializeKeys(b.r)...)
+	return
+}
+
+// transcriptUpdate sets the transcript to Hs(transcript || data...) and
+// returns it as the next challenge
+func transcriptUpdate(transcript *Key, data ...Key) (result Key) {
+	*transcript = *HashToScalar(transcript[:], serializeKeys(data))
+	result = *transcript
+	return
+}
+
+// scalarPowers returns x^0 ... x^(n-1)
+func scalarPowers(x *Key, n int) (result []Key) {
+	result = make([]Key, n)
+	result[0] = Identity
+	for i := 1; i < n; i++ {
+		ScMul(&result[i], &result[i-1], x)
+	}
+	return
+}
+
+// weightedInnerProduct computes the sum of a[i]*b[i]*y^(i+1)
+func weightedInnerProduct(a, b []Key, y *Key) (result Key) {
+	yPower := Identity
+	var tmp Key
+	for i := range a {
+		ScMul(&tmp, &a[i], &b[i])
+		ScMul(&yPower, &yPower, y)
+		ScMulAdd(&result, &tmp, &yPower, &result)
+	}
+	return
+}
+
+// commitInvEight computes (gScalar*G + sum of scalars[i]*points[i]) / 8
+// and overwrites the scalars
+func commitInvEight(result *Key, gScalar *Key, scalars []Key, points []ExtendedGroupElement) {
+	var g Key
+	ScMul(&g, gScalar, &invEight)
+	for i := range scalars {
+		ScMul(&scalars[i], &scalars[i], &invEight)
+	}
+	var p ExtendedGroupElement
+	multiExp(&p, &g, scalars, points)
+	p.ToBytes(result)
+}
+
+// bulletproofPlusSize returns the number of padded amounts m and of inner
+// product rounds for count amounts
+func bulletproofPlusSize(count int) (m, rounds int) {
+	logM := 0
+	for 1<<uint(logM) < count {
+		logM++
+	}
+	m = 1 << uint(logM)
+	rounds = logM + bulletproofPlusLogN
+	return
+}
+
+// ProveBulletproofPlus proves that the commitments masks[i]*G + amounts[i]*H
+// hide 64 bit amounts. All randomness is read from rand.
+func ProveBulletproofPlus(rand io.Reader, amounts []uint64, masks []Key) (result *BulletproofPlus, err error) {
+	if len(amounts) == 0 || len(amounts) > BulletproofPlusMaxOutputs || len(amounts) != len(masks) {
+		err = fmt.Errorf("Cannot prove %d amounts with %d masks", len(amounts), len(masks))
+		return
+	}
+	gens := getBulletproofPlusGens()
+	m, rounds := bulletproofPlusSize(len(amounts))
+	mn := m * bulletproofPlusN
+	var tmp, tmp2 Key
+
+	proof := new(BulletproofPlus)
+	proof.v = make([]Key, len(amounts))
+	for i := range amounts {
+		var mask8, amount8 Key
+		ScFromUint64(&amount8, amounts[i])
+		ScMul(&amount8, &amount8, &invEight)
+		ScMul(&mask8, &masks[i], &invEight)
+		AddKeys2(&proof.v[i], &mask8, &amount8, &H)
+	}
+
+	// aL holds the bits of the amounts and aR = aL - 1
+	var minusOne Key
+	ScNegate(&minusOne, &Identity)
+	aL := make([]Key, mn)
+	aR := make([]Key, mn)
+	for j := 0; j < m; j++ {
+		for i := 0; i < bulletproofPlusN; i++ {
+			if j < len(amounts) && (amounts[j]>>uint(i))&1 == 1 {
+				aL[j*bulletproofPlusN+i] = Identity
+			} else {
+				aR[j*bulletproofPlusN+i] = minusOne
+			}
+		}
+	}
+
+	transcript := gens.transcript
+	transcriptUpdate(&transcript, *HashToScalar(serializeKeys(proof.v)))
+
+	alpha, err := RandomScalarFrom(rand)
+	if err != nil {
+		return
+	}
+	defer wipeKey(alpha)
+	scalars := make([]Key, 0, 2*mn)
+	points := make([]ExtendedGroupElement, 0, 2*mn)
+	for i := 0; i < mn; i++ {
+		scalars = append(scalars, aL[i], aR[i])
+		points = append(points, gens.gi[i], gens.hi[i])
+	}
+	commitInvEight(&proof.a, alpha, scalars, points)
+
+	y := transcriptUpdate(&transcript, proof.a)
+	z := *HashToScalar(y[:])
+	transcript = z
+	if ScIsZero(&y) || ScIsZero(&z) {
+		err = fmt.Errorf("Zero challenge")
+		return
+	}
+	var zSquared Key
+	ScSquare(&zSquared, &z)
+
+	// d[j*N+i] = z^(2*(j+1)) * 2^i
+	var two Key
+	ScFromUint64(&two, 2)
+	d := make([]Key, mn)
+	d[0] = zSquared
+	for i := 1; i < bulletproofPlusN; i++ {
+		ScMul(&d[i], &d[i-1], &two)
+	}
+	for j := 1; j < m; j++ {
+		for i := 0; i < bulletproofPlusN; i++ {
+			ScMul(&d[j*bulletproofPlusN+i], &d[(j-1)*bulletproofPlusN+i], &zSquared)
+		}
+	}
+
+	yPowers := scalarPowers(&y, mn+2)
+	var yInverse Key
+	ScInvert(&yInverse, &y)
+	yInversePowers := scalarPowers(&yInverse, mn)
+
+	aPrime := make([]Key, mn)
+	bPrime := make([]Key, mn)
+	for i := 0; i < mn; i++ {
+		ScSub(&aPrime[i], &aL[i], &z)
+		ScAdd(&bPrime[i], &aR[i], &z)
+		ScMulAdd(&bPrime[i], &d[i], &yPowers[mn-i], &bPrime[i])
+	}
+
+	alpha1 := *alpha
+	defer wipeKey(&alpha1)
+	tmp = Identity
+	for j := range masks {
+		ScMul(&tmp, &tmp, &zSquared)
+		ScMul(&tmp2, &yPowers[mn+1], &tmp)
+		ScMulAdd(&alpha1, &tmp2, &masks[j], &alpha1)
+	}
+
+	gPrime := append([]ExtendedGroupElement(nil), gens.gi[:mn]...)
+	hPrime := append([]ExtendedGroupElement(nil), gens.hi[:mn]...)
+	proof.l = make([]Key, rounds)
+	proof.r = make([]Key, rounds)
+	for round, n := 0, mn/2; n >= 1; round, n = round+1, n/2 {
+		cL := weightedInnerProduct(aPrime[:n], bPrime[n:], &y)
+		aHi := make([]Key, n)
+		for i := range aHi {
+			ScMul(&aHi[i], &aPrime[n+i], &yPowers[n])
+		}
+		cR := weightedInnerProduct(aHi, bPrime[:n], &y)
+
+		var blinds []Key
+		if blinds, err = randomScalars(rand, 2); err != nil {
+			return
+		}
+		dL, dR := &blinds[0], &blinds[1]
+		computeLR(&proof.l[round], &yInversePowers[n], gPrime[n:], hPrime[:n], &gens.h, aPrime[:n], bPrime[n:], &cL, dL)
+		computeLR(&proof.r[round], &yPowers[n], gPrime[:n], hPrime[n:], &gens.h, aPrime[n:], bPrime[:n], &cR, dR)
+
+		x := transcriptUpdate(&transcript, proof.l[round], proof.r[round])
+		if ScIsZero(&x) {
+			err = fmt.Errorf("Zero challenge")
+			return
+		}
+		var xInverse Key
+		ScInvert(&xInverse, &x)
+
+		ScMul(&tmp, &yInversePowers[n], &x)
+		hadamardFold(gPrime, &xInverse, &tmp)
+		hadamardFold(hPrime, &x, &xInverse)
+		gPrime, hPrime = gPrime[:n], hPrime[:n]
+
+		ScMul(&tmp, &xInverse, &yPowers[n])
+		for i := 0; i < n; i++ {
+			ScMul(&tmp2, &aPrime[n+i], &tmp)
+			ScMulAdd(&aPrime[i], &aPrime[i], &x, &tmp2)
+			ScMul(&tmp2, &bPrime[n+i], &x)
+			ScMulAdd(&bPrime[i], &bPrime[i], &xInverse, &tmp2)
+		}
+		aPrime, bPrime = aPrime[:n], bPrime[:n]
+
+		ScSquare(&tmp, &x)
+		ScMulAdd(&alpha1, dL, &tmp, &alpha1)
+		ScSquare(&tmp, &xInverse)
+		ScMulAdd(&alpha1, dR, &tmp, &alpha1)
+		wipeKeys(blinds)
+	}
+
+	blinds, err := randomScalars(rand, 4)
+	if err != nil {
+		return
+	}
+	defer wipeKeys(blinds)
+	r, s, dFinal, eta := &blinds[0], &blinds[1], &blinds[2], &blinds[3]
+	// A1 = r*G' + s*H' + d*G + (r*y*b' + s*y*a')*H, divided by 8
+	ScMul(&tmp, r, &y)
+	ScMul(&tmp, &tmp, &bPrime[0])
+	ScMul(&tmp2, s, &y)
+	ScMul(&tmp2, &tmp2, &aPrime[0])
+	ScAdd(&tmp, &tmp, &tmp2)
+	commitInvEight(&proof.a1, dFinal, []Key{*r, *s, tmp}, []ExtendedGroupElement{gPrime[0], hPrime[0], gens.h})
+	// B = eta*G + r*y*s*H, divided by 8
+	ScMul(&tmp, r, &y)
+	ScMul(&tmp, &tmp, s)
+	commitInvEight(&proof.b, eta, []Key{tmp}, []ExtendedGroupElement{gens.h})
+
+	e := transcriptUpdate(&transcript, proof.a1, proof.b)
+	if ScIsZero(&e) {
+		err = fmt.Errorf("Zero challenge")
+		return
+	}
+	ScMulAdd(&proof.r1, &aPrime[0], &e, r)
+	ScMulAdd(&proof.s1, &bPrime[0], &e, s)
+	ScMulAdd(&proof.d1, dFinal, &e, eta)
+	ScSquare(&tmp, &e)
+	ScMulAdd(&proof.d1, &alpha1, &tmp, &proof.d1)
+	result = proof
+	return
+}
+
+// randomScalars reads n random scalars from rand
+func randomScalars(rand io.Reader, n int) (result []Key, err error) {
+	scalars := make([]Key, n)
+	for i := range scalars {
+		var k *Key
+		if k, err = RandomScalarFrom(rand); err != nil {
+			wipeKeys(scalars)
+			return
+		}
+		scalars[i] = *k
+		wipeKey(k)
+	}
+	result = scalars
+	return
+}
+
+// computeLR computes (sum of a[i]*y*G[i] + b[i]*H[i] + c*H + d*G) / 8
+func computeLR(result *Key, y *Key, g, h []ExtendedGroupElement, hPoint *ExtendedGroupElement, a, b []Key, c, d *Key) {
+	scalars := make([]Key, 0, 2*len(a)+1)
+	points := make([]ExtendedGroupElement, 0, 2*len(a)+1)
+	for i := range a {
+		var ay Key
+		ScMul(&ay, &a[i], y)
+		scalars = append(scalars, ay, b[i])
+		points = append(points, g[i], h[i])
+	}
+	scalars = append(scalars, *c)
+	points = append(points, *hPoint)
+	commitInvEight(result, d, scalars, points)
+}
+
+// hadamardFold sets v[i] = a*v[i] + b*v[i+n] for the first half of v
+func hadamardFold(v []ExtendedGroupElement, a, b *Key) {
+	n := len(v) / 2
+	var zero Key
+	for i := 0; i < n; i++ {
+		multiExp(&v[i], &zero, []Key{*a, *b}, []ExtendedGroupElement{v[i], v[i+n]})
+	}
+}
+
+// Verify checks the range proof against its commitments V
+func (b *BulletproofPlus) Verify() bool {
+	if len(b.v) == 0 || len(b.v) > BulletproofPlusMaxOutputs {
+		return false
+	}
+	m, rounds := bulletproofPlusSize(len(b.v))
+	if len(b.l) != rounds || len(b.r) != rounds {
+		return false
+	}
+	if !ScValid(&b.r1) || !ScValid(&b.s1) || !ScValid(&b.d1) {
+		return false
+	}
+	gens := getBulletproofPlusGens()
+	mn := m * bulletproofPlusN
+	var tmp, tmp2 Key
+
+	// replay the transcript to get the challenges
+	transcript := gens.transcript
+	transcriptUpdate(&transcript, *HashToScalar(serializeKeys(b.v)))
+	y := transcriptUpdate(&transcript, b.a)
+	z := *HashToScalar(y[:])
+	transcript = z
+	if ScIsZero(&y) || ScIsZero(&z) {
+		return false
+	}
+	challenges := make([]Key, rounds)
+	challengeInverses := make([]Key, rounds)
+	for j := range challenges {
+		challenges[j] = transcriptUpdate(&transcript, b.l[j], b.r[j])
+		if ScIsZero(&challenges[j]) {
+			return false
+		}
+		ScInvert(&challengeInverses[j], &challenges[j])
+	}
+	e := transcriptUpdate(&transcript, b.a1, b.b)
+	if ScIsZero(&e) {
+		return false
+	}
+	var eSquared, zSquared, minusESquared Key
+	ScSquare(&eSquared, &e)
+	ScSquare(&zSquared, &z)
+	ScNegate(&minusESquared, &eSquared)
+
+	yPowers := scalarPowers(&y, mn+2)
+	var yInverse Key
+	ScInvert(&yInverse, &y)
+	yInversePowers := scalarPowers(&yInverse, mn)
+
+	var two, sumY, sumD Key
+	ScFromUint64(&two, 2)
+	d := make([]Key, mn)
+	d[0] = zSquared
+	for i := 1; i < bulletproofPlusN; i++ {
+		ScMul(&d[i], &d[i-1], &two)
+	}
+	for j := 1; j < m; j++ {
+		for i := 0; i < bulletproofPlusN; i++ {
+			ScMul(&d[j*bulletproofPlusN+i], &d[(j-1)*bulletproofPlusN+i], &zSquared)
+		}
+	}
+	for i := 0; i < mn; i++ {
+		ScAdd(&sumY, &sumY, &yPowers[i+1])
+		ScAdd(&sumD, &sumD, &d[i])
+	}
+
+	// The proof holds when
+	//   e^2*(A + sum(z + d[i]*y^(MN-i))*Hi - z*sum(Gi) + y^(MN+1)*sum(z^(2(j+1))*V[j]) + k*H
+	//        + sum(x[j]^2*L[j] + x[j]^-2*R[j])) + e*A1 + B
+	//   == e*r1*sum(y^-i*g[i]*Gi) + e*s1*sum(h[i]*Hi) + r1*y*s1*H + d1*G
+	// where k = (z - z^2)*sum(y^i) - z*y^(MN+1)*sum(d) and g[i], h[i] are
+	// the products of the challenges picked by the bits of i. Everything is
+	// moved to the right, so the sum must be the identity.
+	scalars := make([]Key, 0, 2*mn+2*rounds+len(b.v)+4)
+	points := make([]ExtendedGroupElement, 0, cap(scalars))
+	var point ExtendedGroupElement
+	addPoint := func(scalar *Key, k *Key) bool {
+		if !scalarMult8(&point, k) {
+			return false
+		}
+		scalars = append(scalars, *scalar)
+		points = append(points, point)
+		return true
+	}
+
+	var eR1, eS1, eSquaredZ Key
+	ScMul(&eR1, &e, &b.r1)
+	ScMul(&eS1, &e, &b.s1)
+	ScMul(&eSquaredZ, &eSquared, &z)
+	for i := 0; i < mn; i++ {
+		g, h := Identity, Identity
+		for j := 0; j < rounds; j++ {
+			if (i>>uint(rounds-1-j))&1 == 1 {
+				ScMul(&g, &g, &challenges[j])
+				ScMul(&h, &h, &challengeInverses[j])
+			} else {
+				ScMul(&g, &g, &challengeInverses[j])
+				ScMul(&h, &h, &challenges[j])
+			}
+		}
+		ScMul(&tmp, &eR1, &yInversePowers[i])
+		ScMulAdd(&tmp, &tmp, &g, &eSquaredZ)
+		scalars = append(scalars, tmp)
+		points = append(points, gens.gi[i])
+
+		ScMulAdd(&tmp, &d[i], &yPowers[mn-i], &z)
+		ScMul(&tmp, &tmp, &minusESquared)
+		ScMulAdd(&tmp, &eS1, &h, &tmp)
+		scalars = append(scalars, tmp)
+		points = append(points, gens.hi[i])
+	}
+
+	// r1*y*s1 + e^2*(y^(MN+1)*z*sum(d) + (z^2 - z)*sum(y^i))
+	ScMul(&tmp, &yPowers[mn+1], &z)
+	ScMul(&tmp, &tmp, &sumD)
+	ScSub(&tmp2, &zSquared, &z)
+	ScMulAdd(&tmp, &tmp2, &sumY, &tmp)
+	ScMul(&tmp, &tmp, &eSquared)
+	ScMul(&tmp2, &b.r1, &y)
+	ScMulAdd(&tmp, &tmp2, &b.s1, &tmp)
+	scalars = append(scalars, tmp)
+	points = append(points, gens.h)
+
+	if !addPoint(&minusESquared, &b.a) {
+		return false
+	}
+	ScNegate(&tmp, &e)
+	if !addPoint(&tmp, &b.a1) {
+		return false
+	}
+	ScNegate(&tmp, &Identity)
+	if !addPoint(&tmp, &b.b) {
+		return false
+	}
+	ScMul(&tmp2, &minusESquared, &yPowers[mn+1])
+	for j := range b.v {
+		ScMul(&tmp2, &tmp2, &zSquared)
+		if !addPoint(&tmp2, &b.v[j]) {
+			return false
+		}
+	}
+	for j := 0; j < rounds; j++ {
+		ScSquare(&tmp, &challenges[j])
+		ScMul(&tmp, &tmp, &minusESquared)
+		if !addPoint(&tmp, &b.l[j]) {
+			return false
+		}
+		ScSquare(&tmp, &challengeInverses[j])
+		ScMul(&tmp, &tmp, &minusESquared)
+		if !addPoint(&tmp, &b.r[j]) {
+			return false
+		}
+	}
+
+	var sum ExtendedGroupElement
+	var sumBytes Key
+	multiExp(&sum, &b.d1, scalars, points)
+	sum.ToBytes(&sumBytes)
+	return sumBytes == Identity
+}
+
+func ParseBulletproofPlus(buf io.Reader) (result *BulletproofPlus, err error) {
+	b := new(BulletproofPlus)
+	for _, k := range []*Key{&b.a, &b.a1, &b.b, &b.r1, &b.s1, &b.d1} {
+		if *k, err = ParseKey(buf); err != nil {
+			return
+		}
+	}
+	if b.l, err = parseKeyVector(buf); err != nil {
+		return
+	}
+	if b.r, err = parseKeyVector(buf); err != nil {
+		return
+	}
+	result = b
+	return
+}
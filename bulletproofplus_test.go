@@ -0,0 +1,68 @@
+package moneroutil
+
+import (
+	"testing"
+)
+
+func TestBulletproofPlus(t *testing.T) {
+	tests := []struct {
+		name    string
+		amounts []uint64
+	}{
+		{
+			name:    "one amount",
+			amounts: []uint64{0},
+		},
+		{
+			name:    "two amounts",
+			amounts: []uint64{1, 1<<64 - 1},
+		},
+		{
+			name:    "padded to four",
+			amounts: []uint64{1000000000000, 5, 123456789},
+		},
+	}
+	for _, test := range tests {
+		reader := newTestReader(test.name)
+		masks := make([]Key, len(test.amounts))
+		for i := range masks {
+			masks[i] = *RandomScalar()
+		}
+		proof, err := ProveBulletproofPlus(reader, test.amounts, masks)
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+			continue
+		}
+		for i, amount := range test.amounts {
+			var want Key
+			var amountKey Key
+			ScFromUint64(&amountKey, amount)
+			AddKeys2(&want, &masks[i], &amountKey, &H)
+			var got ExtendedGroupElement
+			var gotBytes Key
+			scalarMult8(&got, &proof.v[i])
+			got.ToBytes(&gotBytes)
+			if want != gotBytes {
+				t.Errorf("%s: commitment %d: want %x, got %x", test.name, i, want, gotBytes)
+			}
+		}
+		if !proof.Verify() {
+			t.Errorf("%s: proof not verified", test.name)
+		}
+		tampered := *proof
+		tampered.r1[0] ^= 1
+		if tampered.Verify() {
+			t.Errorf("%s: proof with tampered r1 verified", test.name)
+		}
+		tampered = *proof
+		tampered.v = append([]Key(nil), proof.v...)
+		tampered.v[0], tampered.v[len(tampered.v)-1] = proof.v[len(proof.v)-1], proof.v[0]
+		if len(proof.v) > 1 && tampered.Verify() {
+			t.Errorf("%s: proof with swapped commitments verified", test.name)
+		}
+	}
+	masks := []Key{*RandomScalar()}
+	if _, err := ProveBulletproofPlus(newTestReader("short"), []uint64{1, 2}, masks); err == nil {
+		t.Errorf("mismatched masks: want error")
+	}
+}
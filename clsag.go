@@ -0,0 +1,215 @@
+package moneroutil
+
+import (
+	"fmt"
+	"io"
+)
+
+// CLSAG (Concise Linkable Spontaneous Anonymous Group) Signature
+// It signs for an output key and a commitment to zero at the same ring
+// position. The key image ii is not serialized; it comes from the input.
+type ClsagSig struct {
+	s  []Key
+	c1 Key
+	ii Key
+	d  Key
+}
+
+// clsagDomain pads a domain separator to the size of a key
+func clsagDomain(domain string) (result Key) {
+	copy(result[:], domain)
+	return
+}
+
+func (c *ClsagSig) clone() (result *ClsagSig) {
+	copied := *c
+	copied.s = append([]Key(nil), c.s...)
+	result = &copied
+	return
+}
+
+// S returns a copy of the responses, one per ring member
+func (c *ClsagSig) S() (result []Key) {
+	result = append([]Key(nil), c.s...)
+	return
+}
+
+func (c *ClsagSig) C1() Key {
+	return c.c1
+}
+
+func (c *ClsagSig) KeyImage() Key {
+	return c.ii
+}
+
+// D is the commitment key image divided by 8
+func (c *ClsagSig) D() Key {
+	return c.d
+}
+
+func (c *ClsagSig) Serialize() (result []byte) {
+	result = serializeKeys(c.s)
+	result = append(result, c.c1[:]...)
+	result = append(result, c.d[:]...)
+	return
+}
+
+// clsagHashes returns the aggregation coefficients mu_P and mu_C and the
+// data every round hash starts with
+func clsagHashes(message *Key, ring []CtKey, pseudoOut, keyImage, d *Key) (muP, muC *Key, roundPrefix []byte) {
+	var keys []byte
+	for _, member := range ring {
+		keys = append(keys, member.destination[:]...)
+	}
+	for _, member := range ring {
+		keys = append(keys, member.mask[:]...)
+	}
+	agg0, agg1, round := clsagDomain("CLSAG_agg_0"), clsagDomain("CLSAG_agg_1"), clsagDomain("CLSAG_round")
+	muP = HashToScalar(agg0[:], keys, keyImage[:], d[:], pseudoOut[:])
+	muC = HashToScalar(agg1[:], keys, keyImage[:], d[:], pseudoOut[:])
+	roundPrefix = append(append(round[:], keys...), pseudoOut[:]...)
+	roundPrefix = append(roundPrefix, message[:]...)
+	return
+}
+
+// clsagRound computes the challenge that follows a ring member:
+// Hs(prefix, s*G + c*muP*P + c*muC*C, s*Hp(P) + c*muP*I + c*muC*D)
+// where C is the commitment to zero of the member
+func clsagRound(prefix []byte, s, c, muP, muC *Key, member *CtKey, pseudoOut *Key, keyImage, d *ExtendedGroupElement) (result *Key, err error) {
+	var cP, cC, commitment Key
+	ScMul(&cP, muP, c)
+	ScMul(&cC, muC, c)
+	SubKeys(&commitment, &member.mask, pseudoOut)
+	var p, commitmentPoint ExtendedGroupElement
+	if !p.FromBytes(&member.destination) || !commitmentPoint.FromBytes(&member.mask) {
+		err = fmt.Errorf("Ring member is not a valid point")
+		return
+	}
+	commitmentPoint.FromBytes(&commitment)
+	var l, r ExtendedGroupElement
+	var lBytes, rBytes, zero Key
+	multiExp(&l, s, []Key{cP, cC}, []ExtendedGroupElement{p, commitmentPoint})
+	multiExp(&r, &zero, []Key{*s, cP, cC}, []ExtendedGroupElement{*member.destination.HashToEC(), *keyImage, *d})
+	l.ToBytes(&lBytes)
+	r.ToBytes(&rBytes)
+	result = HashToScalar(prefix, lBytes[:], rBytes[:])
+	return
+}
+
+// CreateClsag signs message over a ring of output keys and their amount
+// commitments. secret owns ring[index].destination, inputMask opens
+// ring[index].mask and pseudoMask opens pseudoOut, which commits to the
+// same amount. All randomness is read from rand.
+func CreateClsag(rand io.Reader, message *Key, ring []CtKey, pseudoOut *Key, index int, secret *SecretKey, inputMask, pseudoMask *Key) (result *ClsagSig, err error) {
+	if index < 0 || index >= len(ring) || ring[index].destination != *secret.PubKey() {
+		err = fmt.Errorf("Secret key does not match ring member %d", index)
+		return
+	}
+	var z, commitment Key
+	defer wipeKey(&z)
+	ScSub(&z, inputMask, pseudoMask)
+	SubKeys(&commitment, &ring[index].mask, pseudoOut)
+	if *z.PubKey() != commitment {
+		err = fmt.Errorf("Masks do not open the commitment of ring member %d", index)
+		return
+	}
+
+	sig := new(ClsagSig)
+	sig.s = make([]Key, len(ring))
+	sig.ii = secret.KeyImage()
+	hp := ring[index].destination.HashToEC()
+	var dPoint, keyImage ExtendedGroupElement
+	var projective ProjectiveGroupElement
+	GeScalarMult(&projective, &z, hp)
+	projective.ToExtended(&dPoint)
+	GeScalarMult(&projective, &invEight, &dPoint)
+	projective.ToBytes(&sig.d)
+	keyImage.FromBytes(&sig.ii)
+	muP, muC, prefix := clsagHashes(message, ring, pseudoOut, &sig.ii, &sig.d)
+
+	a, err := RandomScalarFrom(rand)
+	if err != nil {
+		return
+	}
+	defer wipeKey(a)
+	var aG, aH Key
+	GeScalarMult(&projective, a, hp)
+	projective.ToBytes(&aH)
+	aG = *a.PubKey()
+	c := HashToScalar(prefix, aG[:], aH[:])
+
+	for i := (index + 1) % len(ring); i != index; i = (i + 1) % len(ring) {
+		if i == 0 {
+			sig.c1 = *c
+		}
+		var s *Key
+		if s, err = RandomScalarFrom(rand); err != nil {
+			return
+		}
+		sig.s[i] = *s
+		if c, err = clsagRound(prefix, s, c, muP, muC, &ring[i], pseudoOut, &keyImage, &dPoint); err != nil {
+			return
+		}
+	}
+	if index == 0 {
+		sig.c1 = *c
+	}
+
+	// s = a - c*(muP*secret + muC*z)
+	var sk Key
+	ScMul(&sk, muP, &secret.key)
+	ScMulAdd(&sk, muC, &z, &sk)
+	ScMulSub(&sig.s[index], c, &sk, a)
+	wipeKey(&sk)
+	result = sig
+	return
+}
+
+// Verify checks the signature over message for a ring of output keys and
+// commitments, and the pseudo output commitment of the input
+func (c *ClsagSig) Verify(message *Key, ring []CtKey, pseudoOut *Key) bool {
+	if len(ring) == 0 || len(c.s) != len(ring) || !ScValid(&c.c1) {
+		return false
+	}
+	for i := range c.s {
+		if !ScValid(&c.s[i]) {
+			return false
+		}
+	}
+	var keyImage, d8 ExtendedGroupElement
+	var d8Bytes Key
+	if !keyImage.FromBytes(&c.ii) || !scalarMult8(&d8, &c.d) {
+		return false
+	}
+	if d8.ToBytes(&d8Bytes); d8Bytes == Identity {
+		return false
+	}
+	muP, muC, prefix := clsagHashes(message, ring, pseudoOut, &c.ii, &c.d)
+	challenge := &c.c1
+	for i := range ring {
+		var err error
+		if challenge, err = clsagRound(prefix, &c.s[i], challenge, muP, muC, &ring[i], pseudoOut, &keyImage, &d8); err != nil {
+			return false
+		}
+	}
+	return *challenge == c.c1
+}
+
+// ParseClsagSig reads a signature over a ring of ringSize members
+func ParseClsagSig(buf io.Reader, ringSize int) (result *ClsagSig, err error) {
+	c := new(ClsagSig)
+	c.s = make([]Key, ringSize)
+	for i := range c.s {
+		if c.s[i], err = ParseKey(buf); err != nil {
+			return
+		}
+	}
+	if c.c1, err = ParseKey(buf); err != nil {
+		return
+	}
+	if c.d, err = ParseKey(buf); err != nil {
+		return
+	}
+	result = c
+	return
+}
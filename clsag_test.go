@@ -0,0 +1,74 @@
+package moneroutil
+
+import (
+	"testing"
+)
+
+func TestClsag(t *testing.T) {
+	tests := []struct {
+		name     string
+		ringSize int
+		index    int
+	}{
+		{
+			name:     "single member",
+			ringSize: 1,
+			index:    0,
+		},
+		{
+			name:     "first of 16",
+			ringSize: 16,
+			index:    0,
+		},
+		{
+			name:     "last of 16",
+			ringSize: 16,
+			index:    15,
+		},
+		{
+			name:     "middle of 11",
+			ringSize: 11,
+			index:    4,
+		},
+	}
+	for _, test := range tests {
+		reader := newTestReader(test.name)
+		secret, _ := GenerateSecretKey(reader)
+		inputMask, pseudoMask := RandomScalar(), RandomScalar()
+		var amount Key
+		ScFromUint64(&amount, 1234)
+		ring := make([]CtKey, test.ringSize)
+		for i := range ring {
+			ring[i] = CtKey{destination: *RandomPubKey(), mask: *RandomPubKey()}
+		}
+		ring[test.index].destination = *secret.PubKey()
+		AddKeys2(&ring[test.index].mask, inputMask, &amount, &H)
+		var pseudoOut Key
+		AddKeys2(&pseudoOut, pseudoMask, &amount, &H)
+		message := Key(Keccak256([]byte(test.name)))
+
+		sig, err := CreateClsag(reader, &message, ring, &pseudoOut, test.index, secret, inputMask, pseudoMask)
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+			continue
+		}
+		if sig.ii != secret.KeyImage() {
+			t.Errorf("%s: want key image %x, got %x", test.name, secret.KeyImage(), sig.ii)
+		}
+		if !sig.Verify(&message, ring, &pseudoOut) {
+			t.Errorf("%s: not verified", test.name)
+		}
+		otherMessage := Key(Keccak256(message[:]))
+		if sig.Verify(&otherMessage, ring, &pseudoOut) {
+			t.Errorf("%s: verified with another message", test.name)
+		}
+		var otherPseudoOut Key
+		AddKeys2(&otherPseudoOut, pseudoMask, identity(), &H)
+		if sig.Verify(&message, ring, &otherPseudoOut) {
+			t.Errorf("%s: verified with another pseudo output", test.name)
+		}
+		if _, err = CreateClsag(reader, &message, ring, &otherPseudoOut, test.index, secret, inputMask, pseudoMask); err == nil {
+			t.Errorf("%s: signed for a different amount", test.name)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package moneroutil
+
+import (
+	"fmt"
+	"io"
+)
+
+const (
+	// An extra nonce holds at most this many bytes
+	TxExtraNonceMaxCount = 255
+
+	// Hard fork versions from which the coinbase is a version 2
+	// transaction with a single output, and its outputs have view tags
+	RctCoinbaseVersion = 4
+	ViewTagVersion     = 15
+
+	// From hard fork version 2 until RctCoinbaseVersion the coinbase pays
+	// the reward rounded down to a multiple of this
+	baseRewardClampThreshold = 100000000
+
+	// Before hard fork version 2 the digits of the coinbase amount adding
+	// up to at most this are paid in one output
+	defaultDustThreshold = 2000000000
+)
+
+// CoinbaseBuilder creates the miner transaction paying Reward, the block
+// reward plus fees, to Address. Pools put an ExtraNonce and ReserveSize
+// bytes they fill in later in the tx_extra. From RctCoinbaseVersion the
+// reward is paid in at most MaxOutputs outputs, one if it is zero.
+type CoinbaseBuilder struct {
+	Height      uint64
+	Reward      uint64
+	Address     *Address
+	HFVersion   uint8
+	ExtraNonce  []byte
+	ReserveSize int
+	MaxOutputs  int
+}
+
+// Build creates the coinbase transaction, and returns the transaction
+// secret used for its one-time output keys
+func (b *CoinbaseBuilder) Build(rand io.Reader) (transaction *Transaction, txSecret *SecretKey, err error) {
+	if b.MaxOutputs < 0 {
+		err = fmt.Errorf("Negative maximum output count %d", b.MaxOutputs)
+		return
+	}
+	if len(b.ExtraNonce)+b.ReserveSize > TxExtraNonceMaxCount {
+		err = fmt.Errorf("Extra nonce of %d bytes and %d reserved is too long", len(b.ExtraNonce), b.ReserveSize)
+		return
+	}
+	if b.ReserveSize < 0 {
+		err = fmt.Errorf("Negative reserve size %d", b.ReserveSize)
+		return
+	}
+	secret, err := GenerateSecretKey(rand)
+	if err != nil {
+		return
+	}
+	spendKey, derivation, err := destinationKeys(&TxDestination{Address: b.Address}, secret)
+	if err != nil {
+		return
+	}
+
+	t := new(Transaction)
+	t.version = 1
+	t.unlockTime = b.Height + MinedMoneyUnlockWindow
+	t.vin = []TxInSerializer{&TxInGen{height: b.Height}}
+	for i, amount := range b.outputAmounts() {
+		outputIndex := uint64(i)
+		txOut := &TxOut{amount: amount}
+		if txOut.key, err = DerivePublicKey(&derivation, outputIndex, &spendKey); err != nil {
+			return
+		}
+		if b.HFVersion >= ViewTagVersion {
+			txOut.tagged = true
+			txOut.viewTag = DeriveViewTag(&derivation, outputIndex)
+		}
+		t.vout = append(t.vout, txOut)
+	}
+	txPubKey := secret.PubKey()
+	t.extra = append([]byte{TxExtraTagPubKey}, txPubKey[:]...)
+	if nonceLength := len(b.ExtraNonce) + b.ReserveSize; nonceLength > 0 {
+		t.extra = append(t.extra, TxExtraTagNonce, byte(nonceLength))
+		t.extra = append(t.extra, b.ExtraNonce...)
+		t.extra = append(t.extra, make([]byte, b.ReserveSize)...)
+	}
+	if b.HFVersion >= RctCoinbaseVersion {
+		t.version = 2
+		t.rctSignature = &RctSig{RctSigBase: RctSigBase{sigType: RCTTypeNull}}
+	}
+	transaction = t
+	txSecret = secret
+	return
+}
+
+// outputAmounts splits the reward as monerod's construct_miner_tx: into
+// one output per digit, the dust first, and merges the smallest ones down
+// to MaxOutputs for the genesis block and from RctCoinbaseVersion
+func (b *CoinbaseBuilder) outputAmounts() (result []uint64) {
+	reward := b.Reward
+	if b.HFVersion >= 2 && b.HFVersion < RctCoinbaseVersion {
+		reward -= reward % baseRewardClampThreshold
+	}
+	dustThreshold := uint64(0)
+	if b.HFVersion < 2 {
+		dustThreshold = defaultDustThreshold
+	}
+	chunks, dust := DecomposeAmount(reward, dustThreshold)
+	if dust != 0 {
+		result = append(result, dust)
+	}
+	result = append(result, chunks...)
+	if b.Height == 0 || b.HFVersion >= RctCoinbaseVersion {
+		maxOutputs := b.MaxOutputs
+		if maxOutputs == 0 {
+			maxOutputs = 1
+		}
+		for len(result) > maxOutputs {
+			result[1] += result[0]
+			result = result[1:]
+		}
+	}
+	return
+}
+
+// BlockTemplate builds the coinbase into a block of version HFVersion on
+// top of previousHash, with the hashes of the other transactions to mine.
+// reservedOffset is where the ReserveSize reserved bytes start in blob.
+func (b *CoinbaseBuilder) BlockTemplate(rand io.Reader, timestamp uint64, previousHash Hash, txHashes []Hash) (block *Block, blob []byte, reservedOffset int, err error) {
+	minerTx, _, err := b.Build(rand)
+	if err != nil {
+		return
+	}
+	block = &Block{
+		BlockHeader: BlockHeader{
+			majorVersion: b.HFVersion,
+			minorVersion: b.HFVersion,
+			timeStamp:    timestamp,
+			previousHash: previousHash,
+		},
+		MinerTx:  *minerTx,
+		TxHashes: append([]Hash(nil), txHashes...),
+	}
+	blob = block.Serialize()
+	// the extra is the end of the coinbase prefix, and the reserved bytes
+	// the end of the extra
+	headerLength := len(block.BlockHeader.Serialize())
+	prefixLength := len(minerTx.SerializePrefix())
+	reservedOffset = headerLength + prefixLength - b.ReserveSize
+	return
+}
@@ -0,0 +1,181 @@
+package moneroutil
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestCoinbaseBuilder(t *testing.T) {
+	wallet := newTestWallet(newTestReader("coinbase wallet"))
+	tests := []struct {
+		name       string
+		height     uint64
+		reward     uint64
+		hfVersion  uint8
+		maxOutputs int
+		version    uint32
+		amounts    []uint64
+		tagged     bool
+		extraNonce []byte
+	}{
+		{
+			name:      "v1 digits",
+			height:    1000,
+			reward:    17592186044415,
+			hfVersion: 1,
+			version:   1,
+			amounts:   []uint64{186044415, 2000000000, 90000000000, 500000000000, 7000000000000, 10000000000000},
+		},
+		{
+			name:      "v2 clamped to nothing",
+			height:    1100000,
+			reward:    8123456,
+			hfVersion: 2,
+			version:   1,
+		},
+		{
+			name:      "v2 digits",
+			height:    1100000,
+			reward:    8123456789012,
+			hfVersion: 2,
+			version:   1,
+			amounts:   []uint64{400000000, 3000000000, 20000000000, 100000000000, 8000000000000},
+		},
+		{
+			name:      "genesis",
+			height:    0,
+			reward:    17592186044415,
+			hfVersion: 1,
+			version:   1,
+			amounts:   []uint64{17592186044415},
+		},
+		{
+			name:      "ringct",
+			height:    1800000,
+			reward:    4123456789012,
+			hfVersion: 10,
+			version:   2,
+			amounts:   []uint64{4123456789012},
+		},
+		{
+			name:       "view tags",
+			height:     3000000,
+			reward:     600123450000,
+			hfVersion:  16,
+			version:    2,
+			amounts:    []uint64{600123450000},
+			tagged:     true,
+			extraNonce: []byte("pool"),
+		},
+		{
+			name:       "two outputs",
+			height:     3000000,
+			reward:     600123450000,
+			hfVersion:  16,
+			maxOutputs: 2,
+			version:    2,
+			amounts:    []uint64{123450000, 600000000000},
+			tagged:     true,
+		},
+	}
+	for _, test := range tests {
+		builder := &CoinbaseBuilder{
+			Height:      test.height,
+			Reward:      test.reward,
+			Address:     wallet.address,
+			HFVersion:   test.hfVersion,
+			ExtraNonce:  test.extraNonce,
+			ReserveSize: 8,
+			MaxOutputs:  test.maxOutputs,
+		}
+		transaction, txSecret, err := builder.Build(newTestReader(test.name))
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+			continue
+		}
+		if transaction.version != test.version {
+			t.Errorf("%s: version: want %d, got %d", test.name, test.version, transaction.version)
+		}
+		if !transaction.IsCoinbase() || transaction.vin[0].(*TxInGen).Height() != test.height {
+			t.Errorf("%s: want a generating input at height %d", test.name, test.height)
+		}
+		if transaction.unlockTime != test.height+MinedMoneyUnlockWindow {
+			t.Errorf("%s: unlock time: want %d, got %d", test.name, test.height+MinedMoneyUnlockWindow, transaction.unlockTime)
+		}
+		var amounts []uint64
+		for _, txOut := range transaction.vout {
+			amounts = append(amounts, txOut.amount)
+			if txOut.tagged != test.tagged {
+				t.Errorf("%s: tagged: want %t, got %t", test.name, test.tagged, txOut.tagged)
+			}
+		}
+		if fmt.Sprint(amounts) != fmt.Sprint(test.amounts) {
+			t.Errorf("%s: amounts: want %v, got %v", test.name, test.amounts, amounts)
+		}
+		if owned := wallet.ownedOutputs(transaction); len(owned) != len(test.amounts) {
+			t.Errorf("%s: want all %d outputs owned, got %v", test.name, len(test.amounts), owned)
+		}
+		txPubKey := txSecret.PubKey()
+		wantExtra := append([]byte{TxExtraTagPubKey}, txPubKey[:]...)
+		wantExtra = append(wantExtra, TxExtraTagNonce, byte(len(test.extraNonce)+8))
+		wantExtra = append(wantExtra, test.extraNonce...)
+		wantExtra = append(wantExtra, make([]byte, 8)...)
+		if !bytes.Equal(transaction.extra, wantExtra) {
+			t.Errorf("%s: extra: want %x, got %x", test.name, wantExtra, transaction.extra)
+		}
+		parsed, err := ParseTransaction(bytes.NewReader(transaction.Serialize()))
+		if err != nil {
+			t.Errorf("%s: parse: %s", test.name, err)
+			continue
+		}
+		if parsed.GetHash() != transaction.GetHash() {
+			t.Errorf("%s: parsed hash: want %x, got %x", test.name, transaction.GetHash(), parsed.GetHash())
+		}
+	}
+
+	tooLong := &CoinbaseBuilder{Address: wallet.address, ExtraNonce: make([]byte, 250), ReserveSize: 6}
+	if _, _, err := tooLong.Build(newTestReader("too long")); err == nil {
+		t.Errorf("too long extra nonce: want error")
+	}
+}
+
+func TestCoinbaseBlockTemplate(t *testing.T) {
+	wallet := newTestWallet(newTestReader("coinbase wallet"))
+	builder := &CoinbaseBuilder{
+		Height:      3000000,
+		Reward:      600000000000,
+		Address:     wallet.address,
+		HFVersion:   16,
+		ExtraNonce:  []byte{1, 2, 3},
+		ReserveSize: 16,
+	}
+	previousHash := HexToHash("418015bb9ae982a1975da7d79277c2705727a56894ba0fb246adaabb1f4632e3")
+	txHashes := []Hash{HexToHash("c88ce9783b4f11190d7b9c17a69c1c52200f9faaee8e98dd07e6811175177139")}
+	block, blob, reservedOffset, err := builder.BlockTemplate(newTestReader("template"), 1700000000, previousHash, txHashes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(blob[reservedOffset-3:reservedOffset], []byte{1, 2, 3}) || !bytes.Equal(blob[reservedOffset:reservedOffset+16], make([]byte, 16)) {
+		t.Errorf("reserved offset %d does not follow the extra nonce", reservedOffset)
+	}
+	// a pool fills in the reserved bytes
+	filled := append([]byte(nil), blob...)
+	copy(filled[reservedOffset:], "0123456789abcdef")
+	parsed, err := ParseBlock(bytes.NewReader(filled))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.MajorVersion() != 16 || parsed.PreviousHash() != previousHash || parsed.TimeStamp() != 1700000000 {
+		t.Errorf("header: got version %d, previous hash %x, timestamp %d", parsed.MajorVersion(), parsed.PreviousHash(), parsed.TimeStamp())
+	}
+	if len(parsed.TxHashes) != 1 || parsed.TxHashes[0] != txHashes[0] {
+		t.Errorf("tx hashes: want %x, got %x", txHashes, parsed.TxHashes)
+	}
+	if !bytes.HasSuffix(parsed.MinerTx.Extra(), []byte("0123456789abcdef")) {
+		t.Errorf("extra: want the reserved bytes at the end, got %x", parsed.MinerTx.Extra())
+	}
+	if parsed.MinerTx.GetHash() == block.MinerTx.GetHash() {
+		t.Errorf("want filling the reserved bytes to change the coinbase hash")
+	}
+}
@@ -5,8 +5,9 @@
 package moneroutil
 
 const (
-	MainNetwork = 18
-	TestNetwork = 53
+	MainNetwork  = 18
+	TestNetwork  = 53
+	StageNetwork = 24
 )
 
 // Zero, Identity and L?
@@ -0,0 +1,275 @@
+package moneroutil
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// CryptoNight variants, used for proof of work before RandomX. Variant 1
+// was introduced with block version 7, variant 2 with version 8 and the
+// random math variant R with version 10.
+const (
+	CryptoNightV0 = 0
+	CryptoNightV1 = 1
+	CryptoNightV2 = 2
+	CryptoNightR  = 4
+)
+
+const (
+	cryptoNightMemory     = 1 << 21
+	cryptoNightIterations = 1 << 20
+	cryptoNightInitSize   = 128
+	cryptoNightIndexMask  = (cryptoNightMemory/16 - 1) << 4
+)
+
+// The proof of work of block 202612 is not what its blob hashes to
+var cryptoNightHash202612 = Hash{0x84, 0xf6, 0x47, 0x66, 0x47, 0x5d, 0x51, 0x83, 0x7a, 0xc9, 0xef, 0xbe, 0xf1, 0x92, 0x64, 0x86, 0xe5, 0x85, 0x63, 0xc9, 0x5a, 0x19, 0xfe, 0xf4, 0xae, 0xc3, 0x25, 0x4f, 0x03, 0x00, 0x00, 0x00}
+
+// CryptoNightVariant is the variant used for blocks of a major version.
+// Blocks from version 12 use RandomX instead.
+func CryptoNightVariant(majorVersion uint8) (variant int, err error) {
+	switch {
+	case majorVersion >= 12:
+		err = fmt.Errorf("Block version %d uses RandomX", majorVersion)
+	case majorVersion >= 10:
+		variant = CryptoNightR
+	case majorVersion >= 8:
+		variant = CryptoNightV2
+	case majorVersion == 7:
+		variant = CryptoNightV1
+	}
+	return
+}
+
+// CryptoNightHash is the proof of work hash of a block from before
+// RandomX, at the given height
+func (b *Block) CryptoNightHash(height uint64) (result Hash, err error) {
+	if height == 202612 {
+		result = cryptoNightHash202612
+		return
+	}
+	variant, err := CryptoNightVariant(b.majorVersion)
+	if err != nil {
+		return
+	}
+	result, err = CryptoNight(b.HashingBlob(), variant, height)
+	return
+}
+
+// cnSum adds the 64 bit halves of two blocks
+func cnSum(dst, a, b []byte) {
+	binary.LittleEndian.PutUint64(dst, binary.LittleEndian.Uint64(a)+binary.LittleEndian.Uint64(b))
+	binary.LittleEndian.PutUint64(dst[8:], binary.LittleEndian.Uint64(a[8:])+binary.LittleEndian.Uint64(b[8:]))
+}
+
+func xorBytes(dst, a []byte) {
+	for i := range dst {
+		dst[i] ^= a[i]
+	}
+}
+
+// cryptoNightShuffle mixes the three other 16 byte chunks of the 64 byte
+// line holding offset, from variant 2 on. Variant R also mixes them into
+// out.
+func cryptoNightShuffle(variant int, scratchpad []byte, offset uint32, out, a, b, b1 []byte) {
+	chunk1 := scratchpad[offset^0x10 : offset^0x10+16]
+	chunk2 := scratchpad[offset^0x20 : offset^0x20+16]
+	chunk3 := scratchpad[offset^0x30 : offset^0x30+16]
+	var old1, old2, old3 [16]byte
+	copy(old1[:], chunk1)
+	copy(old2[:], chunk2)
+	copy(old3[:], chunk3)
+	cnSum(chunk1, old3[:], b1)
+	cnSum(chunk3, old2[:], a)
+	cnSum(chunk2, old1[:], b)
+	if variant >= CryptoNightR {
+		xorBytes(old1[:], old2[:])
+		xorBytes(out, old3[:])
+		xorBytes(out, old1[:])
+	}
+}
+
+// cryptoNightSqrt computes the integer square root step of variant 2,
+// using floating point and fixing up rounding errors
+func cryptoNightSqrt(input uint64) (result uint64) {
+	result = uint64(math.Sqrt(float64(input)+18446744073709551616.0)*2.0 - 8589934592.0)
+	s := result >> 1
+	b := result & 1
+	r2 := s*(s+b) + result<<32
+	if r2+b > input {
+		result--
+	}
+	if r2+(1<<32) < input-s {
+		result++
+	}
+	return
+}
+
+// CryptoNight hashes data with a 2 MB scratchpad. height only matters for
+// variant R, whose random math program changes with every block. Variant
+// 1 needs at least 43 bytes of data.
+func CryptoNight(data []byte, variant int, height uint64) (result Hash, err error) {
+	switch variant {
+	case CryptoNightV0, CryptoNightV2, CryptoNightR:
+	case CryptoNightV1:
+		if len(data) < 43 {
+			err = fmt.Errorf("CryptoNight variant 1 needs at least 43 bytes, got %d", len(data))
+			return
+		}
+	default:
+		err = fmt.Errorf("Unknown CryptoNight variant %d", variant)
+		return
+	}
+
+	words := keccak1600(data)
+	state := make([]byte, 200)
+	for i, w := range words {
+		binary.LittleEndian.PutUint64(state[8*i:], w)
+	}
+
+	var tweak1 [8]byte
+	if variant == CryptoNightV1 {
+		copy(tweak1[:], state[192:])
+		xorBytes(tweak1[:], data[35:43])
+	}
+	var divisionResult, sqrtResult uint64
+	b := make([]byte, 32)
+	if variant >= CryptoNightV2 {
+		copy(b[16:], state[64:80])
+		xorBytes(b[16:], state[80:96])
+		divisionResult = binary.LittleEndian.Uint64(state[96:])
+		sqrtResult = binary.LittleEndian.Uint64(state[104:])
+	}
+	var r [9]uint32
+	var code []v4Instruction
+	if variant == CryptoNightR {
+		for i := 0; i < 4; i++ {
+			r[i] = binary.LittleEndian.Uint32(state[96+4*i:])
+		}
+		code = v4RandomMathInit(height)
+	}
+
+	// fill the scratchpad by encrypting the middle of the state
+	scratchpad := make([]byte, cryptoNightMemory)
+	text := make([]byte, cryptoNightInitSize)
+	copy(text, state[64:64+cryptoNightInitSize])
+	roundKeys := aesExpandKey256(state[:32], 10)
+	for i := 0; i < cryptoNightMemory; i += cryptoNightInitSize {
+		for j := 0; j < cryptoNightInitSize; j += 16 {
+			for _, key := range roundKeys {
+				aesRound(text[j:j+16], key[:])
+			}
+		}
+		copy(scratchpad[i:], text)
+	}
+
+	a := make([]byte, 16)
+	c1 := make([]byte, 16)
+	c := make([]byte, 16)
+	d := make([]byte, 16)
+	aOld := make([]byte, 16)
+	for i := 0; i < 16; i++ {
+		a[i] = state[i] ^ state[32+i]
+		b[i] = state[16+i] ^ state[48+i]
+	}
+	for i := 0; i < cryptoNightIterations/2; i++ {
+		j := binary.LittleEndian.Uint32(a) & cryptoNightIndexMask
+		p := scratchpad[j : j+16]
+		copy(c1, p)
+		aesRound(c1, a)
+		if variant >= CryptoNightV2 {
+			cryptoNightShuffle(variant, scratchpad, j, c1, a, b[:16], b[16:])
+		}
+		copy(p, c1)
+		xorBytes(p, b[:16])
+		if variant == CryptoNightV1 {
+			tmp := p[11]
+			index := ((tmp>>3)&6 | tmp&1) << 1
+			p[11] = tmp ^ byte(uint32(0x75310)>>index&0x30)
+		}
+
+		j = binary.LittleEndian.Uint32(c1) & cryptoNightIndexMask
+		p = scratchpad[j : j+16]
+		copy(c, p)
+		if variant == CryptoNightV2 {
+			c0 := binary.LittleEndian.Uint64(c) ^ divisionResult ^ sqrtResult<<32
+			binary.LittleEndian.PutUint64(c, c0)
+			dividend := binary.LittleEndian.Uint64(c1[8:])
+			divisor := uint64(uint32(binary.LittleEndian.Uint64(c1)+uint64(uint32(sqrtResult<<1))) | 0x80000001)
+			divisionResult = uint64(uint32(dividend/divisor)) + (dividend%divisor)<<32
+			sqrtResult = cryptoNightSqrt(binary.LittleEndian.Uint64(c1) + divisionResult)
+		}
+		// the second shuffle adds a from before the random math
+		copy(aOld, a)
+		if variant == CryptoNightR {
+			c0 := binary.LittleEndian.Uint64(c) ^ (uint64(r[0]+r[1]) | uint64(r[2]+r[3])<<32)
+			binary.LittleEndian.PutUint64(c, c0)
+			r[4] = binary.LittleEndian.Uint32(a)
+			r[5] = binary.LittleEndian.Uint32(a[8:])
+			r[6] = binary.LittleEndian.Uint32(b)
+			r[7] = binary.LittleEndian.Uint32(b[16:])
+			r[8] = binary.LittleEndian.Uint32(b[24:])
+			v4RandomMath(code, &r)
+			a0 := binary.LittleEndian.Uint64(a) ^ (uint64(r[2]) | uint64(r[3])<<32)
+			a1 := binary.LittleEndian.Uint64(a[8:]) ^ (uint64(r[0]) | uint64(r[1])<<32)
+			binary.LittleEndian.PutUint64(a, a0)
+			binary.LittleEndian.PutUint64(a[8:], a1)
+		}
+		hi, lo := bits.Mul64(binary.LittleEndian.Uint64(c1), binary.LittleEndian.Uint64(c))
+		binary.LittleEndian.PutUint64(d, hi)
+		binary.LittleEndian.PutUint64(d[8:], lo)
+		if variant == CryptoNightV2 {
+			xorBytes(scratchpad[j^0x10:j^0x10+16], d)
+			xorBytes(d, scratchpad[j^0x20:j^0x20+16])
+		}
+		if variant >= CryptoNightV2 {
+			cryptoNightShuffle(variant, scratchpad, j, c1, aOld, b[:16], b[16:])
+		}
+		cnSum(a, a, d)
+		// a, c = c ^ (a + d), a + d
+		for k := range a {
+			a[k], c[k] = a[k]^c[k], a[k]
+		}
+		if variant == CryptoNightV1 {
+			xorBytes(c[8:], tweak1[:])
+		}
+		copy(p, c)
+		if variant >= CryptoNightV2 {
+			copy(b[16:], b[:16])
+		}
+		copy(b[:16], c1)
+	}
+
+	// fold the scratchpad back into the state with the second key
+	copy(text, state[64:64+cryptoNightInitSize])
+	roundKeys = aesExpandKey256(state[32:64], 10)
+	for i := 0; i < cryptoNightMemory; i += cryptoNightInitSize {
+		for j := 0; j < cryptoNightInitSize; j += 16 {
+			xorBytes(text[j:j+16], scratchpad[i+j:i+j+16])
+			for _, key := range roundKeys {
+				aesRound(text[j:j+16], key[:])
+			}
+		}
+	}
+	copy(state[64:], text)
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint64(state[8*i:])
+	}
+	keccakF1600(&words)
+	for i, w := range words {
+		binary.LittleEndian.PutUint64(state[8*i:], w)
+	}
+	switch state[0] & 3 {
+	case 0:
+		result = blake256(state)
+	case 1:
+		result = groestl256(state)
+	case 2:
+		result = jh256(state)
+	case 3:
+		result = skein512256(state)
+	}
+	return
+}
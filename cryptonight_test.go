@@ -0,0 +1,143 @@
+package moneroutil
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestCryptoNight(t *testing.T) {
+	tests := []struct {
+		name    string
+		dataHex string
+		variant int
+		height  uint64
+		hashHex string
+	}{
+		{
+			name:    "v0",
+			dataHex: hex.EncodeToString([]byte("This is a test")),
+			variant: CryptoNightV0,
+			hashHex: "a084f01d1437a09c6985401b60d43554ae105802c5f5d8a9b3253649c0be6605",
+		},
+		{
+			name:    "v0 2",
+			dataHex: hex.EncodeToString([]byte("de omnibus dubitandum")),
+			variant: CryptoNightV0,
+			hashHex: "2f8e3df40bd11f9ac90c743ca8e32bb391da4fb98612aa3b6cdc639ee00b31f5",
+		},
+		{
+			name:    "v0 empty",
+			dataHex: "",
+			variant: CryptoNightV0,
+			hashHex: "eb14e8a833fac6fe9a43b57b336789c46ffe93f2868452240720607b14387e11",
+		},
+		{
+			name:    "v0 3",
+			dataHex: hex.EncodeToString([]byte("abundans cautela non nocet")),
+			variant: CryptoNightV0,
+			hashHex: "722fa8ccd594d40e4a41f3822734304c8d5eff7e1b528408e2229da38ba553c4",
+		},
+		{
+			// finalized with JH
+			name:    "v0 4",
+			dataHex: hex.EncodeToString([]byte("caveat emptor")),
+			variant: CryptoNightV0,
+			hashHex: "bbec2cacf69866a8e740380fe7b818fc78f8571221742d729d9d02d7f8989b87",
+		},
+		{
+			// finalized with Skein
+			name:    "v0 5",
+			dataHex: hex.EncodeToString([]byte("ex nihilo nihil fit")),
+			variant: CryptoNightV0,
+			hashHex: "b1257de4efc5ce28c6b40ceb1c6c8f812a64634eb3e81c5220bee9b2b76a6f05",
+		},
+		{
+			name:    "v1",
+			dataHex: "00000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+			variant: CryptoNightV1,
+			hashHex: "b5a7f63abb94d07d1a6445c36c07c7e8327fe61b1647e391b4c7edae5de57a3d",
+		},
+		{
+			name:    "v2",
+			dataHex: "5468697320697320612074657374205468697320697320612074657374205468697320697320612074657374",
+			variant: CryptoNightV2,
+			hashHex: "353fdc068fd47b03c04b9431e005e00b68c2168a3cc7335c8b9b308156591a4f",
+		},
+		{
+			name:    "R",
+			dataHex: "5468697320697320612074657374205468697320697320612074657374205468697320697320612074657374",
+			variant: CryptoNightR,
+			height:  1806260,
+			hashHex: "f759588ad57e758467295443a9bd71490abff8e9dad1b95b6bf2f5d0d78387bc",
+		},
+	}
+	for _, test := range tests {
+		data, _ := hex.DecodeString(test.dataHex)
+		want := HexToHash(test.hashHex)
+		got, err := CryptoNight(data, test.variant, test.height)
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("%s: want %x, got %x", test.name, want, got)
+		}
+	}
+}
+
+func TestCryptoNightErrors(t *testing.T) {
+	if _, err := CryptoNight(make([]byte, 42), CryptoNightV1, 0); err == nil {
+		t.Errorf("short v1 data: want error")
+	}
+	if _, err := CryptoNight(nil, 3, 0); err == nil {
+		t.Errorf("unknown variant: want error")
+	}
+}
+
+func TestCryptoNightVariant(t *testing.T) {
+	tests := []struct {
+		majorVersion uint8
+		variant      int
+	}{
+		{1, CryptoNightV0},
+		{6, CryptoNightV0},
+		{7, CryptoNightV1},
+		{8, CryptoNightV2},
+		{9, CryptoNightV2},
+		{10, CryptoNightR},
+		{11, CryptoNightR},
+	}
+	for _, test := range tests {
+		variant, err := CryptoNightVariant(test.majorVersion)
+		if err != nil {
+			t.Errorf("%d: %s", test.majorVersion, err)
+			continue
+		}
+		if variant != test.variant {
+			t.Errorf("%d: want %d, got %d", test.majorVersion, test.variant, variant)
+		}
+	}
+	if _, err := CryptoNightVariant(12); err == nil {
+		t.Errorf("12: want error")
+	}
+}
+
+func TestCryptoNightFinalizers(t *testing.T) {
+	tests := []struct {
+		name    string
+		hash    func([]byte) Hash
+		hashHex string
+	}{
+		{"blake256", blake256, "716f6e863f744b9ac22c97ec7b76ea5f5908bc5b2f67c61510bfc4751384ea7a"},
+		{"groestl256", groestl256, "1a52d11d550039be16107f9c58db9ebcc417f16f736adb2502567119f0083467"},
+		{"jh256", jh256, "46e64619c18bb0a92a5e87185a47eef83ca747b8fcc8e1412921357e326df434"},
+		{"skein512256", skein512256, "39ccc4554a8b31853b9de7a1fe638a24cce6b35a55f2431009e18780335d2621"},
+	}
+	for _, test := range tests {
+		want := HexToHash(test.hashHex)
+		got := test.hash(nil)
+		if got != want {
+			t.Errorf("%s: want %x, got %x", test.name, want, got)
+		}
+	}
+}
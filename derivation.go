@@ -0,0 +1,61 @@
+package moneroutil
+
+import (
+	"fmt"
+)
+
+// GenerateKeyDerivation computes the shared secret 8*sec*pub between a
+// transaction key and a view key. The sender uses the transaction secret and
+// the recipient's view public key, the recipient its view secret and the
+// transaction public key.
+func GenerateKeyDerivation(pub *Key, sec *SecretKey) (derivation Key, err error) {
+	point := new(ExtendedGroupElement)
+	if !point.FromBytes(pub) {
+		err = fmt.Errorf("Public key %x is not a valid point", *pub)
+		return
+	}
+	var p1 ProjectiveGroupElement
+	var p2 CompletedGroupElement
+	var p3 ExtendedGroupElement
+	GeScalarMult(&p1, &sec.key, point)
+	GeMul8(&p2, &p1)
+	p2.ToExtended(&p3)
+	p3.ToBytes(&derivation)
+	return
+}
+
+// DerivationToScalar hashes a derivation and an output index to a scalar
+func DerivationToScalar(derivation *Key, outputIndex uint64) (result *Key) {
+	result = HashToScalar(derivation[:], Uint64ToBytes(outputIndex))
+	return
+}
+
+// DeriveViewTag computes the view tag of an output, the first byte of
+// Keccak256("view_tag" || derivation || outputIndex). It lets recipients
+// skip most outputs that are not theirs without deriving the output key.
+func DeriveViewTag(derivation *Key, outputIndex uint64) byte {
+	h := Keccak256([]byte("view_tag"), derivation[:], Uint64ToBytes(outputIndex))
+	return h[0]
+}
+
+// DerivePublicKey computes the one-time public key of an output:
+// DerivationToScalar(derivation, outputIndex)*G + base
+func DerivePublicKey(derivation *Key, outputIndex uint64, base *Key) (result Key, err error) {
+	basePoint := new(ExtendedGroupElement)
+	if !basePoint.FromBytes(base) {
+		err = fmt.Errorf("Public key %x is not a valid point", *base)
+		return
+	}
+	AddKeys(&result, DerivationToScalar(derivation, outputIndex).PubKey(), base)
+	return
+}
+
+// DeriveSecretKey computes the one-time secret key of an output owned by
+// the spend secret base: DerivationToScalar(derivation, outputIndex) + base
+func DeriveSecretKey(derivation *Key, outputIndex uint64, base *SecretKey) (result *SecretKey) {
+	scalar := DerivationToScalar(derivation, outputIndex)
+	result = new(SecretKey)
+	ScAdd(&result.key, scalar, &base.key)
+	wipeKey(scalar)
+	return
+}
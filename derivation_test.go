@@ -0,0 +1,89 @@
+package moneroutil
+
+import (
+	"testing"
+)
+
+func TestKeyDerivation(t *testing.T) {
+	reader := newTestReader("derivation")
+	for i := 0; i < 10; i++ {
+		txSecret, txPubKey, _ := GenerateKeyPair(reader)
+		viewSecret, viewPubKey, _ := GenerateKeyPair(reader)
+		spendSecret, spendPubKey, _ := GenerateKeyPair(reader)
+		senderDerivation, err := GenerateKeyDerivation(viewPubKey, txSecret)
+		if err != nil {
+			t.Fatal(err)
+		}
+		receiverDerivation, err := GenerateKeyDerivation(txPubKey, viewSecret)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if senderDerivation != receiverDerivation {
+			t.Errorf("%d: derivation: sender %x, receiver %x", i, senderDerivation, receiverDerivation)
+		}
+		outputIndex := uint64(i)
+		outputKey, err := DerivePublicKey(&senderDerivation, outputIndex, spendPubKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		outputSecret := DeriveSecretKey(&receiverDerivation, outputIndex, spendSecret)
+		if *outputSecret.PubKey() != outputKey {
+			t.Errorf("%d: want %x, got %x", i, outputKey, *outputSecret.PubKey())
+		}
+		otherKey, _ := DerivePublicKey(&senderDerivation, outputIndex+1, spendPubKey)
+		if otherKey == outputKey {
+			t.Errorf("%d: output index not bound", i)
+		}
+	}
+	bad := HexToKey("0200000000000000000000000000000000000000000000000000000000000000")
+	secret, _, _ := GenerateKeyPair(reader)
+	if _, err := GenerateKeyDerivation(&bad, secret); err == nil {
+		t.Errorf("want error for invalid point")
+	}
+}
+
+func TestGenerateKeyDerivation(t *testing.T) {
+	// generate_key_derivation cases from github.com/monero-project/monero/tests/crypto/tests.txt
+	tests := []struct {
+		pubKey     string
+		secret     string
+		derivation string
+	}{
+		{"fdfd97d2ea9f1c25df773ff2c973d885653a3ee643157eb0ae2b6dd98f0b6984", "eb2bd1cf0c5e074f9dbf38ebbc99c316f54e21803048c687a3bb359f7a713b02", "4e0bd2c41325a1b89a9f7413d4d05e0a5a4936f241dccc3c7d0c539ffe00ef67"},
+		{"1ebf8c3c296bb91708b09d9a8e0639ccfd72556976419c7dc7e6dfd7599218b9", "e49f363fd5c8fc1f8645983647ca33d7ec9db2d255d94cd538a3cc83153c5f04", "72903ec8f9919dfcec6efb5535490527b573b3d77f9890386d373c02bf368934"},
+		{"3e3047a633b1f84250ae11b5c8e8825a3df4729f6cbe4713b887db62f268187d", "6df324e24178d91c640b75ab1c6905f8e6bb275bc2c2a5d9b9ecf446765a5a05", "9dcac9c9e87dd96a4115d84d587218d8bf165a0527153b1c306e562fe39a46ab"},
+	}
+	for _, test := range tests {
+		pubKey := HexToKey(test.pubKey)
+		secret := HexToKey(test.secret)
+		want := HexToKey(test.derivation)
+		got, err := GenerateKeyDerivation(&pubKey, NewSecretKey(&secret))
+		if err != nil {
+			t.Errorf("%s: %s", test.pubKey, err)
+			continue
+		}
+		if want != got {
+			t.Errorf("%s: want %x, got %x", test.pubKey, want, got)
+		}
+	}
+}
+
+func TestDeriveViewTag(t *testing.T) {
+	tests := []struct {
+		derivation  string
+		outputIndex uint64
+		want        byte
+	}{
+		{"0fc47054f355ced4d67de73bfa12e4c78ff19089548fffa7d07a674741860f97", 0, 0x76},
+		{"0fc47054f355ced4d67de73bfa12e4c78ff19089548fffa7d07a674741860f97", 1, 0xd6},
+		{"0fc47054f355ced4d67de73bfa12e4c78ff19089548fffa7d07a674741860f97", 2, 0x87},
+		{"a36ba7b4d31349ad278a6df8f77adb76748b59f4929348e67dd92adb9fa174dc", 0, 0x70},
+	}
+	for _, test := range tests {
+		derivation := HexToKey(test.derivation)
+		got := DeriveViewTag(&derivation, test.outputIndex)
+		if got != test.want {
+			t.Errorf("%s %d: want %02x, got %02x", test.derivation, test.outputIndex, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package moneroutil
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"sort"
+)
+
+const (
+	// Seconds between blocks the difficulty aims for, before and from hard
+	// fork version 2
+	DifficultyTargetV1 = 60
+	DifficultyTargetV2 = 120
+
+	// The difficulty is computed from the DifficultyWindow blocks before
+	// the last DifficultyLag ones, ignoring the DifficultyCut earliest and
+	// latest timestamps. Monero has kept these the same in every hard fork.
+	DifficultyWindow      = 720
+	DifficultyLag         = 15
+	DifficultyCut         = 60
+	DifficultyBlocksCount = DifficultyWindow + DifficultyLag
+)
+
+// Difficulty is a 128 bit difficulty or cumulative difficulty
+type Difficulty struct {
+	hi, lo uint64
+}
+
+// NewDifficulty makes the difficulty hi * 2^64 + lo
+func NewDifficulty(hi, lo uint64) Difficulty {
+	return Difficulty{hi: hi, lo: lo}
+}
+
+// DifficultyFromUint64 makes a difficulty that fits in 64 bits
+func DifficultyFromUint64(x uint64) Difficulty {
+	return Difficulty{lo: x}
+}
+
+// Hi is the top 64 bits, the difficulty_top64 of monerod
+func (d Difficulty) Hi() uint64 {
+	return d.hi
+}
+
+// Lo is the low 64 bits
+func (d Difficulty) Lo() uint64 {
+	return d.lo
+}
+
+func (d Difficulty) IsZero() bool {
+	return d.hi == 0 && d.lo == 0
+}
+
+// Cmp returns -1, 0 or 1 as d is less than, equal to or greater than x
+func (d Difficulty) Cmp(x Difficulty) int {
+	switch {
+	case d.hi < x.hi || d.hi == x.hi && d.lo < x.lo:
+		return -1
+	case d == x:
+		return 0
+	}
+	return 1
+}
+
+// Add is d + x, wrapping at 128 bits
+func (d Difficulty) Add(x Difficulty) (result Difficulty) {
+	var carry uint64
+	result.lo, carry = bits.Add64(d.lo, x.lo, 0)
+	result.hi, _ = bits.Add64(d.hi, x.hi, carry)
+	return
+}
+
+// Sub is d - x, wrapping at 128 bits
+func (d Difficulty) Sub(x Difficulty) (result Difficulty) {
+	var borrow uint64
+	result.lo, borrow = bits.Sub64(d.lo, x.lo, 0)
+	result.hi, _ = bits.Sub64(d.hi, x.hi, borrow)
+	return
+}
+
+func (d Difficulty) Big() *big.Int {
+	result := new(big.Int).SetUint64(d.hi)
+	result.Lsh(result, 64)
+	return result.Or(result, new(big.Int).SetUint64(d.lo))
+}
+
+func (d Difficulty) String() string {
+	return d.Big().String()
+}
+
+// DifficultyTarget is the block time in seconds of hard fork version
+func DifficultyTarget(version uint8) uint64 {
+	if version < 2 {
+		return DifficultyTargetV1
+	}
+	return DifficultyTargetV2
+}
+
+// NextDifficulty is the cryptonote difficulty of the next block for hard
+// fork version, from the timestamps and cumulative difficulties of the
+// blocks before it, oldest first. Only the last DifficultyBlocksCount
+// blocks are used.
+func NextDifficulty(timestamps []uint64, cumulativeDifficulties []Difficulty, version uint8) (result Difficulty, err error) {
+	if len(timestamps) != len(cumulativeDifficulties) {
+		err = fmt.Errorf("Have %d timestamps but %d cumulative difficulties", len(timestamps), len(cumulativeDifficulties))
+		return
+	}
+	if len(timestamps) > DifficultyBlocksCount {
+		timestamps = timestamps[len(timestamps)-DifficultyBlocksCount:]
+		cumulativeDifficulties = cumulativeDifficulties[len(cumulativeDifficulties)-DifficultyBlocksCount:]
+	}
+	// the most recent DifficultyLag blocks are left out
+	if len(timestamps) > DifficultyWindow {
+		timestamps = timestamps[:DifficultyWindow]
+		cumulativeDifficulties = cumulativeDifficulties[:DifficultyWindow]
+	}
+	length := len(timestamps)
+	if length <= 1 {
+		result = DifficultyFromUint64(1)
+		return
+	}
+	sorted := append([]uint64(nil), timestamps...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	cutBegin, cutEnd := 0, length
+	if length > DifficultyWindow-2*DifficultyCut {
+		cutBegin = (length - (DifficultyWindow - 2*DifficultyCut) + 1) / 2
+		cutEnd = cutBegin + (DifficultyWindow - 2*DifficultyCut)
+	}
+	timeSpan := sorted[cutEnd-1] - sorted[cutBegin]
+	if timeSpan == 0 {
+		timeSpan = 1
+	}
+	totalWork := cumulativeDifficulties[cutEnd-1].Sub(cumulativeDifficulties[cutBegin])
+	if totalWork.IsZero() {
+		err = fmt.Errorf("Cumulative difficulty does not increase")
+		return
+	}
+
+	// (totalWork * target + timeSpan - 1) / timeSpan in 192 bits
+	target := DifficultyTarget(version)
+	var w [3]uint64
+	var carry, c uint64
+	carry, w[0] = bits.Mul64(totalWork.lo, target)
+	w[2], w[1] = bits.Mul64(totalWork.hi, target)
+	w[1], c = bits.Add64(w[1], carry, 0)
+	w[2] += c
+	w[0], c = bits.Add64(w[0], timeSpan-1, 0)
+	w[1], c = bits.Add64(w[1], 0, c)
+	w[2] += c
+	if w[2] >= timeSpan {
+		err = fmt.Errorf("Difficulty overflows 128 bits")
+		return
+	}
+	var rem uint64
+	result.hi, rem = bits.Div64(w[2], w[1], timeSpan)
+	result.lo, _ = bits.Div64(rem, w[0], timeSpan)
+	return
+}
+
+// CheckHash reports whether powHash, read as a little endian number, meets
+// difficulty, that is whether their product fits in 256 bits
+func CheckHash(powHash Hash, difficulty Difficulty) bool {
+	var product [6]uint64
+	for i, d := range [2]uint64{difficulty.lo, difficulty.hi} {
+		var carry uint64
+		for j := 0; j < 4; j++ {
+			hi, lo := bits.Mul64(binary.LittleEndian.Uint64(powHash[8*j:]), d)
+			var c uint64
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			product[i+j], c = bits.Add64(product[i+j], lo, 0)
+			carry = hi + c
+		}
+		product[i+4] += carry
+	}
+	return product[4] == 0 && product[5] == 0
+}
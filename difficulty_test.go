@@ -0,0 +1,95 @@
+package moneroutil
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNextDifficulty(t *testing.T) {
+	// blocks every spacing seconds, each adding difficulty
+	chain := func(n int, spacing uint64, difficulty Difficulty) (timestamps []uint64, cumulativeDifficulties []Difficulty) {
+		var cumulative Difficulty
+		for i := 0; i < n; i++ {
+			cumulative = cumulative.Add(difficulty)
+			timestamps = append(timestamps, uint64(i)*spacing)
+			cumulativeDifficulties = append(cumulativeDifficulties, cumulative)
+		}
+		return
+	}
+	short, shortDifficulties := chain(10, 120, DifficultyFromUint64(1000))
+	shuffled, shuffledDifficulties := chain(10, 120, DifficultyFromUint64(1000))
+	shuffled[2], shuffled[7] = shuffled[7], shuffled[2]
+	long, longDifficulties := chain(800, 120, NewDifficulty(1, 0))
+	// a slower last DifficultyLag blocks do not count
+	for i := len(long) - DifficultyLag; i < len(long); i++ {
+		long[i] *= 10
+	}
+	tests := []struct {
+		name                   string
+		timestamps             []uint64
+		cumulativeDifficulties []Difficulty
+		version                uint8
+		want                   Difficulty
+	}{
+		{"empty", nil, nil, 16, DifficultyFromUint64(1)},
+		{"one block", []uint64{5}, []Difficulty{DifficultyFromUint64(5)}, 16, DifficultyFromUint64(1)},
+		{"short", short, shortDifficulties, 16, DifficultyFromUint64(1000)},
+		{"short v1", short, shortDifficulties, 1, DifficultyFromUint64(500)},
+		{"shuffled", shuffled, shuffledDifficulties, 16, DifficultyFromUint64(1000)},
+		{"round up", []uint64{0, 7}, []Difficulty{{}, DifficultyFromUint64(1)}, 16, DifficultyFromUint64(18)},
+		{"same timestamp", []uint64{3, 3}, []Difficulty{{}, DifficultyFromUint64(1)}, 16, DifficultyFromUint64(120)},
+		{"128 bit window", long, longDifficulties, 16, NewDifficulty(1, 0)},
+	}
+	for _, test := range tests {
+		got, err := NextDifficulty(test.timestamps, test.cumulativeDifficulties, test.version)
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("%s: want %s, got %s", test.name, test.want, got)
+		}
+	}
+
+	if _, err := NextDifficulty([]uint64{0, 1}, []Difficulty{{}}, 16); err == nil {
+		t.Errorf("mismatched lengths: want error")
+	}
+	if _, err := NextDifficulty([]uint64{0, 1}, []Difficulty{{}, NewDifficulty(1<<63, 0)}, 16); err == nil {
+		t.Errorf("overflow: want error")
+	}
+}
+
+func TestCheckHash(t *testing.T) {
+	max := NewDifficulty(math.MaxUint64, math.MaxUint64)
+	tests := []struct {
+		name       string
+		hashHex    string
+		difficulty Difficulty
+		want       bool
+	}{
+		{"zero", "0000000000000000000000000000000000000000000000000000000000000000", max, true},
+		{"one", "0100000000000000000000000000000000000000000000000000000000000000", max, true},
+		{"2^255 by 1", "0000000000000000000000000000000000000000000000000000000000000080", DifficultyFromUint64(1), true},
+		{"2^255 by 2", "0000000000000000000000000000000000000000000000000000000000000080", DifficultyFromUint64(2), false},
+		{"2^128 by max", "0000000000000000000000000000000001000000000000000000000000000000", max, true},
+		{"2^192 by 2^64", "0000000000000000000000000000000000000000000000000100000000000000", NewDifficulty(1, 0), false},
+		{"2^192-1 by 2^64", "ffffffffffffffffffffffffffffffffffffffffffffffff0000000000000000", NewDifficulty(1, 0), true},
+		{"2^192-1 by 2^64+1", "ffffffffffffffffffffffffffffffffffffffffffffffff0000000000000000", NewDifficulty(1, 1), false},
+		{"max by 1", "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", DifficultyFromUint64(1), true},
+		{"max by 2", "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", DifficultyFromUint64(2), false},
+	}
+	for _, test := range tests {
+		got := CheckHash(HexToHash(test.hashHex), test.difficulty)
+		if got != test.want {
+			t.Errorf("%s: want %t, got %t", test.name, test.want, got)
+		}
+	}
+}
+
+func TestDifficultyString(t *testing.T) {
+	want := "340282366920938463463374607431768211455"
+	got := NewDifficulty(math.MaxUint64, math.MaxUint64).String()
+	if got != want {
+		t.Errorf("want %s, got %s", want, got)
+	}
+}
@@ -803,6 +803,13 @@ func (p *ProjectiveGroupElement) FromBytes(s *Key) {
 	FeMul(&p.X, &p.X, &p.Z)
 }
 
+func (p *ProjectiveGroupElement) ToExtended(r *ExtendedGroupElement) {
+	FeMul(&r.X, &p.X, &p.Z)
+	FeMul(&r.Y, &p.Y, &p.Z)
+	FeSquare(&r.Z, &p.Z)
+	FeMul(&r.T, &p.X, &p.Y)
+}
+
 func (p *ExtendedGroupElement) Zero() {
 	p.X.Zero()
 	p.Y.One()
@@ -973,6 +980,15 @@ func geMixedSub(r *CompletedGroupElement, p *ExtendedGroupElement, q *PreCompute
 	FeAdd(&r.T, &t0, &r.T)
 }
 
+// r = p + q
+func GeAdd(r, p, q *ExtendedGroupElement) {
+	var c CachedGroupElement
+	var t CompletedGroupElement
+	q.ToCached(&c)
+	geAdd(&t, p, &c)
+	t.ToExtended(r)
+}
+
 // r = 8 * t
 func GeMul8(r *CompletedGroupElement, t *ProjectiveGroupElement) {
 	var u ProjectiveGroupElement
@@ -1663,6 +1679,7 @@ func signum(a int64) int64 {
 	return a>>63 - ((-a) >> 63)
 }
 
+// ScValid reports whether s is canonical, that is fully reduced below l.
 func ScValid(s *Key) bool {
 	s0 := load4(s[:])
 	s1 := load4(s[4:])
@@ -3046,3 +3063,83 @@ func ScReduce32(s *Key) {
 	s[30] = byte(s11 >> 9)
 	s[31] = byte(s11 >> 17)
 }
+
+// Output:
+//   s[0]+256*s[1]+...+256^31*s[31] = ab mod l
+//   where l = 2^252 + 27742317777372353535851937790883648493.
+func ScMul(s, a, b *Key) {
+	ScMulAdd(s, a, b, &Zero)
+}
+
+// Output:
+//   s[0]+256*s[1]+...+256^31*s[31] = aa mod l
+func ScSquare(s, a *Key) {
+	ScMulAdd(s, a, a, &Zero)
+}
+
+// Output:
+//   s[0]+256*s[1]+...+256^31*s[31] = -a mod l
+func ScNegate(s, a *Key) {
+	ScSub(s, &Zero, a)
+}
+
+// ScFromUint64 sets s to the scalar with the little-endian value of val.
+func ScFromUint64(s *Key, val uint64) {
+	*s = Zero
+	for i := 0; i < 8; i++ {
+		s[i] = byte(val >> uint(8*i))
+	}
+}
+
+// scSquareMultiply squares y n times and then multiplies the result by x.
+func scSquareMultiply(y *Key, n int, x *Key) {
+	for i := 0; i < n; i++ {
+		ScSquare(y, y)
+	}
+	ScMul(y, y, x)
+}
+
+// ScInvert sets s to 1/a mod l, computed as a^(l-2) with a fixed
+// addition chain. The inverse of zero is zero.
+func ScInvert(s, a *Key) {
+	var _1, _10, _100, _11, _101, _111, _1001, _1011, _1111, y Key
+	_1 = *a
+	ScSquare(&_10, &_1)
+	ScSquare(&_100, &_10)
+	ScMul(&_11, &_10, &_1)
+	ScMul(&_101, &_10, &_11)
+	ScMul(&_111, &_10, &_101)
+	ScMul(&_1001, &_10, &_111)
+	ScMul(&_1011, &_10, &_1001)
+	ScMul(&_1111, &_100, &_1011)
+	ScMul(&y, &_1111, &_1)
+
+	scSquareMultiply(&y, 123+3, &_101)
+	scSquareMultiply(&y, 2+2, &_11)
+	scSquareMultiply(&y, 1+4, &_1111)
+	scSquareMultiply(&y, 1+4, &_1111)
+	scSquareMultiply(&y, 4, &_1001)
+	scSquareMultiply(&y, 2, &_11)
+	scSquareMultiply(&y, 1+4, &_1111)
+	scSquareMultiply(&y, 1+3, &_101)
+	scSquareMultiply(&y, 3+3, &_101)
+	scSquareMultiply(&y, 3, &_111)
+	scSquareMultiply(&y, 1+4, &_1111)
+	scSquareMultiply(&y, 2+3, &_111)
+	scSquareMultiply(&y, 2+2, &_11)
+	scSquareMultiply(&y, 1+4, &_1011)
+	scSquareMultiply(&y, 2+4, &_1011)
+	scSquareMultiply(&y, 6+4, &_1001)
+	scSquareMultiply(&y, 2+2, &_11)
+	scSquareMultiply(&y, 3+2, &_11)
+	scSquareMultiply(&y, 3+2, &_11)
+	scSquareMultiply(&y, 1+4, &_1001)
+	scSquareMultiply(&y, 1+3, &_111)
+	scSquareMultiply(&y, 2+4, &_1111)
+	scSquareMultiply(&y, 1+4, &_1011)
+	scSquareMultiply(&y, 3, &_101)
+	scSquareMultiply(&y, 2+4, &_1111)
+	scSquareMultiply(&y, 3, &_101)
+	scSquareMultiply(&y, 1+2, &_11)
+	*s = y
+}
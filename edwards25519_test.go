@@ -1,6 +1,7 @@
 package moneroutil
 
 import (
+	"math/big"
 	"testing"
 )
 
@@ -1612,3 +1613,67 @@ func TestScValid(t *testing.T) {
 		}
 	}
 }
+
+func scalarToBig(s *Key) *big.Int {
+	var be [KeyLength]byte
+	for i := range s {
+		be[KeyLength-1-i] = s[i]
+	}
+	return new(big.Int).SetBytes(be[:])
+}
+
+func TestScalarArithmetic(t *testing.T) {
+	l := scalarToBig(&L)
+	scalars := []*Key{new(Key), identity()}
+	for i := 0; i < 20; i++ {
+		scalars = append(scalars, RandomScalar())
+	}
+	for _, a := range scalars {
+		aBig := scalarToBig(a)
+		for _, b := range scalars {
+			bBig := scalarToBig(b)
+			var got Key
+			ScMul(&got, a, b)
+			want := new(big.Int).Mul(aBig, bBig)
+			want.Mod(want, l)
+			if scalarToBig(&got).Cmp(want) != 0 {
+				t.Errorf("mul %x %x: want %x, got %x", a, b, want, got)
+			}
+		}
+		var got Key
+		ScSquare(&got, a)
+		want := new(big.Int).Mul(aBig, aBig)
+		want.Mod(want, l)
+		if scalarToBig(&got).Cmp(want) != 0 {
+			t.Errorf("square %x: want %x, got %x", a, want, got)
+		}
+		ScNegate(&got, a)
+		want.Neg(aBig)
+		want.Mod(want, l)
+		if scalarToBig(&got).Cmp(want) != 0 {
+			t.Errorf("negate %x: want %x, got %x", a, want, got)
+		}
+		ScInvert(&got, a)
+		want.ModInverse(aBig, l)
+		if aBig.Sign() == 0 {
+			want.SetInt64(0)
+		}
+		if scalarToBig(&got).Cmp(want) != 0 {
+			t.Errorf("invert %x: want %x, got %x", a, want, got)
+		}
+	}
+}
+
+func TestScFromUint64(t *testing.T) {
+	tests := []uint64{0, 1, 255, 256, 10000000000000, 1<<64 - 1}
+	for _, val := range tests {
+		var got Key
+		ScFromUint64(&got, val)
+		if !ScValid(&got) {
+			t.Errorf("%d: not canonical", val)
+		}
+		if scalarToBig(&got).Uint64() != val {
+			t.Errorf("want %d, got %x", val, got)
+		}
+	}
+}
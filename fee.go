@@ -0,0 +1,247 @@
+package moneroutil
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+const (
+	// Blocks up to this weight earn the full reward, by hard fork version
+	FullRewardZoneV1 = 20000
+	FullRewardZoneV2 = 60000
+	FullRewardZoneV5 = 300000
+
+	// Hard fork version from which fees are paid per byte of weight
+	// rather than per started kB
+	PerByteFeeVersion = 8
+
+	// Hard fork version from which per byte fees come in four tiers set by
+	// the lower of the short and long term median block weights
+	FeeScaling2021Version = 15
+
+	dynamicFeePerKBBaseFee               = 2000000000
+	dynamicFeePerKBBaseFeeV5             = 400000000
+	dynamicFeePerKBBaseBlockReward       = 10000000000000
+	dynamicFeeReferenceTransactionWeight = 3000
+
+	// fees are rounded up to 8 of the 12 decimal places
+	feeQuantizationMask = 10000
+
+	// fee tiers are rounded up to this many significant digits
+	feeScaling2021RoundingPlaces = 2
+)
+
+// Fee priorities, as chosen in the wallet. Older fee algorithms only know
+// the first three.
+const (
+	FeePriorityUnimportant = iota + 1
+	FeePriorityNormal
+	FeePriorityElevated
+	FeePriorityPriority
+)
+
+// MinBlockWeight is the median block weight below which the full block
+// reward is always earned
+func MinBlockWeight(version uint8) uint64 {
+	switch {
+	case version < 2:
+		return FullRewardZoneV1
+	case version < 5:
+		return FullRewardZoneV2
+	}
+	return FullRewardZoneV5
+}
+
+// weightClawback is the weight added to a bulletproof transaction with
+// more than two outputs, so that its weight grows linearly with the number
+// of outputs even though its proof grows logarithmically. nPaddedOutputs is
+// the number of amounts the proofs can cover.
+func weightClawback(plus bool, nPaddedOutputs uint64) (result uint64) {
+	if nPaddedOutputs <= 2 {
+		return
+	}
+	fixedKeys := uint64(9)
+	if plus {
+		fixedKeys = 6
+	}
+	// the size of a two output proof, per output
+	bpBase := KeyLength * (fixedKeys + 7*2) / 2
+	nlr := uint64(bits.Len64(nPaddedOutputs-1)) + bulletproofPlusLogN
+	bpSize := KeyLength * (fixedKeys + 2*nlr)
+	result = (bpBase*nPaddedOutputs - bpSize) * 4 / 5
+	return
+}
+
+// Weight is the size of the transaction blob, plus the bulletproof
+// clawback for transactions with more than two outputs. Fees and block
+// sizes are based on weight. Pruned transactions lack their signatures,
+// so their weight cannot be computed from the blob.
+func (t *Transaction) Weight() (result uint64) {
+	result = uint64(len(t.Serialize()))
+	if t.version < 2 || t.rctSignature == nil {
+		return
+	}
+	r := t.rctSignature
+	var proofLRs []int
+	switch r.sigType {
+	case RCTTypeBulletproof, RCTTypeBulletproof2, RCTTypeCLSAG:
+		for _, proof := range r.bulletproofs {
+			proofLRs = append(proofLRs, len(proof.l))
+		}
+	case RCTTypeBulletproofPlus:
+		for _, proof := range r.bulletproofsPlus {
+			proofLRs = append(proofLRs, len(proof.l))
+		}
+	default:
+		return
+	}
+	// a proof with n rounds covers 2^(n-6) amounts
+	var nPaddedOutputs uint64
+	for _, nLR := range proofLRs {
+		if nLR >= bulletproofPlusLogN {
+			nPaddedOutputs += 1 << uint(nLR-bulletproofPlusLogN)
+		}
+	}
+	result += weightClawback(r.sigType == RCTTypeBulletproofPlus, nPaddedOutputs)
+	return
+}
+
+// div128 divides the 128 bit number hi:lo by d
+func div128(hi, lo, d uint64) (qhi, qlo uint64) {
+	qhi = hi / d
+	qlo, _ = bits.Div64(hi%d, lo, d)
+	return
+}
+
+// roundMoneyUp rounds amount up to the given number of significant digits
+func roundMoneyUp(amount uint64, significantDigits int) uint64 {
+	scale := uint64(1)
+	for rest := amount; rest >= 10; rest /= 10 {
+		scale *= 10
+	}
+	for i := 1; i < significantDigits && scale > 1; i++ {
+		scale /= 10
+	}
+	return (amount + scale - 1) / scale * scale
+}
+
+// DynamicBaseFeeTiers are the per byte fees of the four priorities from
+// FeeScaling2021Version, for a block reward and the short and long term
+// median block weights. The lowest tier is the minimum relay fee.
+func DynamicBaseFeeTiers(blockReward, medianBlockWeight, longTermMedianBlockWeight uint64, version uint8) (result [4]uint64) {
+	minBlockWeight := MinBlockWeight(version)
+	if medianBlockWeight < minBlockWeight {
+		medianBlockWeight = minBlockWeight
+	}
+	if longTermMedianBlockWeight < minBlockWeight {
+		longTermMedianBlockWeight = minBlockWeight
+	}
+	feeWeight := medianBlockWeight
+	if longTermMedianBlockWeight < feeWeight {
+		feeWeight = longTermMedianBlockWeight
+	}
+	hi, lo := bits.Mul64(blockReward, dynamicFeeReferenceTransactionWeight)
+	hi, lo = div128(hi, lo, feeWeight)
+	_, low := div128(hi, lo, feeWeight)
+	medium := 16 * low
+	high := 4 * medium * feeWeight / (32 * dynamicFeeReferenceTransactionWeight)
+	if high < 4*medium {
+		high = 4 * medium
+	}
+	for i, fee := range []uint64{low, 4 * low, medium, high} {
+		result[i] = roundMoneyUp(fee, feeScaling2021RoundingPlaces)
+	}
+	return
+}
+
+// DynamicBaseFee is the minimum fee for a block reward and the short and
+// long term median weights of recent blocks. From PerByteFeeVersion it is
+// per byte of weight, before that per kB and rounded up to 8 decimal
+// places. The long term median is only used from FeeScaling2021Version.
+func DynamicBaseFee(blockReward, medianBlockWeight, longTermMedianBlockWeight uint64, version uint8) (result uint64) {
+	if version >= FeeScaling2021Version {
+		result = DynamicBaseFeeTiers(blockReward, medianBlockWeight, longTermMedianBlockWeight, version)[0]
+		return
+	}
+	minBlockWeight := MinBlockWeight(version)
+	if medianBlockWeight < minBlockWeight {
+		medianBlockWeight = minBlockWeight
+	}
+	if version >= PerByteFeeVersion {
+		hi, lo := bits.Mul64(blockReward, dynamicFeeReferenceTransactionWeight)
+		hi, lo = div128(hi, lo, medianBlockWeight)
+		_, lo = div128(hi, lo, minBlockWeight)
+		result = lo / 5
+		return
+	}
+	feeBase := uint64(dynamicFeePerKBBaseFee)
+	if version >= 5 {
+		feeBase = dynamicFeePerKBBaseFeeV5
+	}
+	unscaledFeeBase := feeBase * minBlockWeight / medianBlockWeight
+	hi, lo := bits.Mul64(unscaledFeeBase, blockReward)
+	_, lo = div128(hi, lo, dynamicFeePerKBBaseBlockReward)
+	result = (lo + feeQuantizationMask - 1) / feeQuantizationMask * feeQuantizationMask
+	return
+}
+
+// FeeMultiplier is what the base fee is multiplied by for a priority,
+// using the fee algorithm of the hard fork version. From
+// FeeScaling2021Version each priority has its own base fee instead, see
+// DynamicBaseFeeTiers.
+func FeeMultiplier(priority int, version uint8) (result uint64, err error) {
+	var multipliers []uint64
+	switch {
+	case version >= FeeScaling2021Version:
+		err = fmt.Errorf("Version %d has a base fee per priority rather than multipliers", version)
+		return
+	case version >= PerByteFeeVersion:
+		multipliers = []uint64{1, 5, 25, 1000}
+	case version >= 5:
+		multipliers = []uint64{1, 4, 20, 166}
+	case version >= 3:
+		multipliers = []uint64{1, 20, 166}
+	default:
+		multipliers = []uint64{1, 2, 3}
+	}
+	if priority < 1 || priority > len(multipliers) {
+		err = fmt.Errorf("Bad fee priority %d for version %d", priority, version)
+		return
+	}
+	result = multipliers[priority-1]
+	return
+}
+
+// FeeForWeight is the fee for a transaction of the given weight at a base
+// fee and multiplier. Per byte fees are rounded up to 8 decimal places,
+// per kB fees are charged for every started kB.
+func FeeForWeight(weight, baseFee, multiplier uint64, version uint8) (result uint64) {
+	if version >= PerByteFeeVersion {
+		result = weight * baseFee * multiplier
+		result = (result + feeQuantizationMask - 1) / feeQuantizationMask * feeQuantizationMask
+		return
+	}
+	result = (weight + 1023) / 1024 * baseFee * multiplier
+	return
+}
+
+// EstimateFee is the fee a wallet pays for a transaction of the given
+// weight at a priority, for the block reward and the short and long term
+// median block weights at the tip of the chain
+func EstimateFee(weight, blockReward, medianBlockWeight, longTermMedianBlockWeight uint64, version uint8, priority int) (result uint64, err error) {
+	if version >= FeeScaling2021Version {
+		tiers := DynamicBaseFeeTiers(blockReward, medianBlockWeight, longTermMedianBlockWeight, version)
+		if priority < 1 || priority > len(tiers) {
+			err = fmt.Errorf("Bad fee priority %d for version %d", priority, version)
+			return
+		}
+		result = FeeForWeight(weight, tiers[priority-1], 1, version)
+		return
+	}
+	multiplier, err := FeeMultiplier(priority, version)
+	if err != nil {
+		return
+	}
+	result = FeeForWeight(weight, DynamicBaseFee(blockReward, medianBlockWeight, longTermMedianBlockWeight, version), multiplier, version)
+	return
+}
@@ -0,0 +1,142 @@
+package moneroutil
+
+import (
+	"testing"
+)
+
+func TestDynamicBaseFee(t *testing.T) {
+	tests := []struct {
+		name                      string
+		blockReward               uint64
+		medianBlockWeight         uint64
+		longTermMedianBlockWeight uint64
+		version                   uint8
+		want                      uint64
+	}{
+		{"2021 scaling", 600000000000, 300000, 300000, 16, 20000},
+		{"2021 scaling below minimum median", 600000000000, 1000, 0, 16, 20000},
+		{"2021 scaling double median", 600000000000, 600000, 600000, 16, 5000},
+		{"2021 scaling low long term median", 600000000000, 600000, 300000, 15, 20000},
+		{"per byte", 600000000000, 300000, 0, 14, 4000},
+		{"per byte below minimum median", 600000000000, 1000, 0, 14, 4000},
+		{"per byte double median", 600000000000, 600000, 0, 8, 2000},
+		{"per kB v5", 600000000000, 300000, 0, 7, 24000000},
+		{"per kB 128 bit", 17000000000000, 300000, 0, 5, 680000000},
+		{"per kB v1", 10000000000000, 20000, 0, 1, 2000000000},
+		{"per kB quantized", 123456789, 300000, 0, 7, 10000},
+	}
+	for _, test := range tests {
+		got := DynamicBaseFee(test.blockReward, test.medianBlockWeight, test.longTermMedianBlockWeight, test.version)
+		if test.want != got {
+			t.Errorf("%s: want %d, got %d", test.name, test.want, got)
+		}
+	}
+}
+
+func TestDynamicBaseFeeTiers(t *testing.T) {
+	tests := []struct {
+		name                      string
+		blockReward               uint64
+		medianBlockWeight         uint64
+		longTermMedianBlockWeight uint64
+		want                      [4]uint64
+	}{
+		// as returned by monerod's get_fee_estimate at tail emission
+		{"tail emission", 600000000000, 300000, 300000, [4]uint64{20000, 80000, 320000, 4000000}},
+		{"rounded up", 600000000000, 310000, 320000, [4]uint64{19000, 75000, 300000, 3900000}},
+	}
+	for _, test := range tests {
+		got := DynamicBaseFeeTiers(test.blockReward, test.medianBlockWeight, test.longTermMedianBlockWeight, 16)
+		if test.want != got {
+			t.Errorf("%s: want %v, got %v", test.name, test.want, got)
+		}
+	}
+}
+
+func TestEstimateFee(t *testing.T) {
+	tests := []struct {
+		name     string
+		weight   uint64
+		version  uint8
+		priority int
+		want     uint64
+	}{
+		{"2021 scaling unimportant", 1500, 16, FeePriorityUnimportant, 30000000},
+		{"2021 scaling normal", 1500, 16, FeePriorityNormal, 120000000},
+		{"2021 scaling elevated", 1500, 16, FeePriorityElevated, 480000000},
+		{"2021 scaling priority", 1500, 16, FeePriorityPriority, 6000000000},
+		{"per byte unimportant", 1500, 14, FeePriorityUnimportant, 6000000},
+		{"per byte normal", 1500, 14, FeePriorityNormal, 30000000},
+		{"per byte priority", 1500, 14, FeePriorityPriority, 6000000000},
+		{"per kB normal", 1500, 7, FeePriorityNormal, 192000000},
+		{"per kB one kB", 1024, 7, FeePriorityUnimportant, 24000000},
+	}
+	for _, test := range tests {
+		got, err := EstimateFee(test.weight, 600000000000, 300000, 300000, test.version, test.priority)
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+			continue
+		}
+		if test.want != got {
+			t.Errorf("%s: want %d, got %d", test.name, test.want, got)
+		}
+	}
+	if _, err := FeeMultiplier(FeePriorityPriority, 4); err == nil {
+		t.Errorf("want error for priority 4 before version 5")
+	}
+	if _, err := FeeMultiplier(0, 14); err == nil {
+		t.Errorf("want error for priority 0")
+	}
+	if _, err := FeeMultiplier(FeePriorityNormal, 16); err == nil {
+		t.Errorf("want error for multipliers from version %d", FeeScaling2021Version)
+	}
+	if _, err := EstimateFee(1500, 600000000000, 300000, 300000, 16, 5); err == nil {
+		t.Errorf("want error for priority 5")
+	}
+}
+
+func TestTransactionWeight(t *testing.T) {
+	randomKeys := func(n int) (result []Key) {
+		for i := 0; i < n; i++ {
+			result = append(result, *RandomPubKey())
+		}
+		return
+	}
+	tests := []struct {
+		name     string
+		sigType  uint8
+		nOutputs int
+		nLR      int
+		clawback uint64
+	}{
+		{"bulletproof plus 2 outputs", RCTTypeBulletproofPlus, 2, 7, 0},
+		{"bulletproof plus 3 outputs", RCTTypeBulletproofPlus, 3, 8, 460},
+		{"bulletproof plus 16 outputs", RCTTypeBulletproofPlus, 16, 10, 3430},
+		{"clsag 3 outputs", RCTTypeCLSAG, 3, 8, 537},
+		{"simple", RCTTypeSimple, 3, 0, 0},
+	}
+	for _, test := range tests {
+		transaction := &Transaction{}
+		transaction.version = 2
+		txIn, _ := NewTxInToKey(0, []uint64{1, 2}, *RandomPubKey())
+		transaction.vin = []TxInSerializer{txIn}
+		r := &RctSig{}
+		r.sigType = test.sigType
+		for i := 0; i < test.nOutputs; i++ {
+			transaction.vout = append(transaction.vout, NewTxOut(0, *RandomPubKey()))
+			r.ecdhInfo = append(r.ecdhInfo, EcdhTuple{})
+			r.outPk = append(r.outPk, CtKey{mask: *RandomPubKey()})
+		}
+		switch test.sigType {
+		case RCTTypeBulletproofPlus:
+			r.bulletproofsPlus = []BulletproofPlus{{l: randomKeys(test.nLR), r: randomKeys(test.nLR)}}
+		case RCTTypeCLSAG:
+			r.bulletproofs = []Bulletproof{{l: randomKeys(test.nLR), r: randomKeys(test.nLR)}}
+		}
+		transaction.rctSignature = r
+		want := uint64(len(transaction.Serialize())) + test.clawback
+		if got := transaction.Weight(); want != got {
+			t.Errorf("%s: want %d, got %d", test.name, want, got)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package moneroutil
+
+import (
+	"encoding/binary"
+)
+
+// Groestl-256, one of the CryptoNight finalizers. The state is an 8x8
+// matrix of bytes stored column by column, as the input is laid out.
+
+var groestlMixRow = [8]byte{2, 2, 3, 4, 5, 3, 5, 7}
+
+var (
+	groestlShiftP = [8]int{0, 1, 2, 3, 4, 5, 6, 7}
+	groestlShiftQ = [8]int{1, 3, 5, 7, 0, 2, 4, 6}
+)
+
+// gfMul multiplies in GF(2^8) with the AES polynomial
+func gfMul(a, b byte) (result byte) {
+	for ; b != 0; b >>= 1 {
+		if b&1 != 0 {
+			result ^= a
+		}
+		a = aesXtime(a)
+	}
+	return
+}
+
+// groestlPermutation applies P, or Q when q is set, to the state
+func groestlPermutation(state *[64]byte, q bool) {
+	shift := groestlShiftP
+	if q {
+		shift = groestlShiftQ
+	}
+	for round := 0; round < 10; round++ {
+		// AddRoundConstant
+		for col := 0; col < 8; col++ {
+			if q {
+				for row := 0; row < 7; row++ {
+					state[8*col+row] ^= 0xff
+				}
+				state[8*col+7] ^= 0xff ^ byte(col<<4) ^ byte(round)
+			} else {
+				state[8*col] ^= byte(col<<4) ^ byte(round)
+			}
+		}
+		// SubBytes and ShiftBytes
+		var t [64]byte
+		for col := 0; col < 8; col++ {
+			for row := 0; row < 8; row++ {
+				t[8*col+row] = aesSbox[state[8*((col+shift[row])%8)+row]]
+			}
+		}
+		// MixBytes
+		for col := 0; col < 8; col++ {
+			for row := 0; row < 8; row++ {
+				var b byte
+				for k := 0; k < 8; k++ {
+					b ^= gfMul(t[8*col+k], groestlMixRow[(k-row+8)%8])
+				}
+				state[8*col+row] = b
+			}
+		}
+	}
+}
+
+func groestlCompress(h *[64]byte, block []byte) {
+	var p, q [64]byte
+	for i := range p {
+		p[i] = h[i] ^ block[i]
+		q[i] = block[i]
+	}
+	groestlPermutation(&p, false)
+	groestlPermutation(&q, true)
+	for i := range h {
+		h[i] ^= p[i] ^ q[i]
+	}
+}
+
+func groestl256(data []byte) (result Hash) {
+	var h [64]byte
+	// the initial value encodes the output length in bits
+	h[62] = 1
+	nBlocks := uint64(len(data)/64) + 1
+	if len(data)%64 > 55 {
+		nBlocks++
+	}
+	padded := make([]byte, nBlocks*64)
+	copy(padded, data)
+	padded[len(data)] = 0x80
+	binary.BigEndian.PutUint64(padded[len(padded)-8:], nBlocks)
+	for i := 0; i < len(padded); i += 64 {
+		groestlCompress(&h, padded[i:i+64])
+	}
+	x := h
+	groestlPermutation(&x, false)
+	for i := 32; i < 64; i++ {
+		result[i-32] = x[i] ^ h[i]
+	}
+	return
+}
@@ -0,0 +1,252 @@
+package moneroutil
+
+import (
+	"fmt"
+)
+
+// HardFork is the height a hard fork version starts at, and the time it
+// was scheduled for
+type HardFork struct {
+	version   uint8
+	height    uint64
+	timestamp uint64
+}
+
+func (h *HardFork) Version() uint8 {
+	return h.version
+}
+
+func (h *HardFork) Height() uint64 {
+	return h.height
+}
+
+func (h *HardFork) Timestamp() uint64 {
+	return h.timestamp
+}
+
+var (
+	mainnetHardForks = []HardFork{
+		{1, 1, 1341378000},
+		{2, 1009827, 1442763710},
+		{3, 1141317, 1458558528},
+		{4, 1220516, 1483574400},
+		{5, 1288616, 1489520158},
+		{6, 1400000, 1503046577},
+		{7, 1546000, 1521303150},
+		{8, 1685555, 1535889547},
+		{9, 1686275, 1535889548},
+		{10, 1788000, 1549792439},
+		{11, 1788720, 1550225678},
+		{12, 1978433, 1571419280},
+		{13, 2210000, 1598180817},
+		{14, 2210720, 1598180818},
+		{15, 2688888, 1656629117},
+		{16, 2689608, 1656629118},
+	}
+	testnetHardForks = []HardFork{
+		{1, 1, 1341378000},
+		{2, 624634, 1445355000},
+		{3, 800500, 1472415034},
+		{4, 801219, 1472415035},
+		{5, 802660, 1472415036 + 86400*180},
+		{6, 971400, 1501709789},
+		{7, 1057027, 1512211236},
+		{8, 1057058, 1533211200},
+		{9, 1057778, 1533297600},
+		{10, 1154318, 1550153694},
+		{11, 1155038, 1550225678},
+		{12, 1308737, 1569582000},
+		{13, 1543939, 1599069376},
+		{14, 1544659, 1599069377},
+		{15, 1982800, 1652727000},
+		{16, 1983520, 1652813400},
+	}
+	stagenetHardForks = []HardFork{
+		{1, 1, 1341378000},
+		{2, 32000, 1521000000},
+		{3, 33000, 1521120000},
+		{4, 34000, 1521240000},
+		{5, 35000, 1521360000},
+		{6, 36000, 1521480000},
+		{7, 37000, 1521600000},
+		{8, 176456, 1537821770},
+		{9, 177176, 1537821771},
+		{10, 269000, 1550153694},
+		{11, 269720, 1550225678},
+		{12, 454721, 1571419280},
+		{13, 675405, 1598180817},
+		{14, 676125, 1598180818},
+		{15, 1151000, 1656629117},
+		{16, 1151720, 1656629118},
+	}
+)
+
+// HardForks returns a copy of the hard forks of network, one of
+// MainNetwork, TestNetwork or StageNetwork, in order
+func HardForks(network int) (result []HardFork, err error) {
+	var forks []HardFork
+	switch network {
+	case MainNetwork:
+		forks = mainnetHardForks
+	case TestNetwork:
+		forks = testnetHardForks
+	case StageNetwork:
+		forks = stagenetHardForks
+	default:
+		err = fmt.Errorf("Unknown network %d", network)
+		return
+	}
+	result = append([]HardFork(nil), forks...)
+	return
+}
+
+// HardForkVersion is the version blocks at height on network must have.
+// The genesis block is version 1.
+func HardForkVersion(network int, height uint64) (version uint8, err error) {
+	forks, err := HardForks(network)
+	if err != nil {
+		return
+	}
+	version = 1
+	for _, fork := range forks {
+		if height < fork.height {
+			break
+		}
+		version = fork.version
+	}
+	return
+}
+
+// Whether outputs may or must have view tags
+const (
+	ViewTagsForbidden = iota
+	ViewTagsAllowed
+	ViewTagsRequired
+)
+
+// Rules are the consensus rules that change between hard fork versions.
+// Ring sizes count the real output, and a MaxRingSize or MaxOutputs of 0
+// is no limit. BaseFee depends on the chain rather than the version: set
+// it from DynamicBaseFee to have fees checked. SortedExtra is a wallet and
+// relay convention rather than consensus, so RulesForVersion leaves it off.
+type Rules struct {
+	Version               uint8
+	MinTransactionVersion uint32
+	MaxTransactionVersion uint32
+	RctTypes              []uint8
+	MinRingSize           int
+	MaxRingSize           int
+	MinOutputs            int
+	MaxOutputs            int
+	SortedKeyImages       bool
+	ViewTags              int
+	PerByteFee            bool
+	DifficultyTarget      uint64
+	MinBlockWeight        uint64
+	BaseFee               uint64
+	SortedExtra           bool
+}
+
+// MaxHardForkVersion is the latest hard fork version this package knows
+const MaxHardForkVersion = 16
+
+// RulesForVersion returns the rules of hard fork version
+func RulesForVersion(version uint8) (result *Rules, err error) {
+	if version < 1 || version > MaxHardForkVersion {
+		err = fmt.Errorf("Unknown hard fork version %d", version)
+		return
+	}
+	r := &Rules{
+		Version:               version,
+		MinTransactionVersion: 1,
+		MaxTransactionVersion: 1,
+		MinRingSize:           1,
+		MinOutputs:            1,
+		SortedKeyImages:       version >= 7,
+		PerByteFee:            version >= PerByteFeeVersion,
+		DifficultyTarget:      DifficultyTarget(version),
+		MinBlockWeight:        MinBlockWeight(version),
+	}
+	if version >= RctCoinbaseVersion {
+		r.MaxTransactionVersion = 2
+	}
+	if version >= 6 {
+		r.MinTransactionVersion = 2
+	}
+
+	switch {
+	case version >= 16:
+		r.RctTypes = []uint8{RCTTypeBulletproofPlus}
+	case version == 15:
+		r.RctTypes = []uint8{RCTTypeCLSAG, RCTTypeBulletproofPlus}
+	case version == 14:
+		r.RctTypes = []uint8{RCTTypeCLSAG}
+	case version == 13:
+		r.RctTypes = []uint8{RCTTypeBulletproof2, RCTTypeCLSAG}
+	case version >= 11:
+		r.RctTypes = []uint8{RCTTypeBulletproof2}
+	case version == 10:
+		r.RctTypes = []uint8{RCTTypeBulletproof, RCTTypeBulletproof2}
+	case version == 9:
+		r.RctTypes = []uint8{RCTTypeBulletproof}
+	case version == 8:
+		r.RctTypes = []uint8{RCTTypeFull, RCTTypeSimple, RCTTypeBulletproof}
+	case version >= 4:
+		r.RctTypes = []uint8{RCTTypeFull, RCTTypeSimple}
+	}
+
+	switch {
+	case version >= 15:
+		r.MinRingSize, r.MaxRingSize = 16, 16
+	case version >= 8:
+		r.MinRingSize, r.MaxRingSize = 11, 11
+	case version == 7:
+		r.MinRingSize = 7
+	case version == 6:
+		r.MinRingSize = 5
+	case version >= 2:
+		r.MinRingSize = 3
+	}
+
+	// bulletproofs cover at most 16 outputs, and from version 12 every
+	// transaction has a change output
+	if version >= 9 {
+		r.MaxOutputs = BulletproofPlusMaxOutputs
+	}
+	if version >= 12 {
+		r.MinOutputs = 2
+	}
+
+	switch {
+	case version > ViewTagVersion:
+		r.ViewTags = ViewTagsRequired
+	case version == ViewTagVersion:
+		r.ViewTags = ViewTagsAllowed
+	}
+	result = r
+	return
+}
+
+// AllowsRctType reports whether version 2 transactions may use RingCT
+// signature type sigType
+func (r *Rules) AllowsRctType(sigType uint8) bool {
+	for _, allowed := range r.RctTypes {
+		if allowed == sigType {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckHardForkVersion checks that a block at height on network has the
+// major version of its hard fork
+func (b *BlockHeader) CheckHardForkVersion(network int, height uint64) (err error) {
+	version, err := HardForkVersion(network, height)
+	if err != nil {
+		return
+	}
+	if b.majorVersion != version {
+		err = fmt.Errorf("Block at height %d has version %d, want %d", height, b.majorVersion, version)
+	}
+	return
+}
@@ -0,0 +1,105 @@
+package moneroutil
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestHardForkVersion(t *testing.T) {
+	tests := []struct {
+		network int
+		height  uint64
+		want    uint8
+	}{
+		{MainNetwork, 0, 1},
+		{MainNetwork, 1009826, 1},
+		{MainNetwork, 1009827, 2},
+		{MainNetwork, 1978432, 11},
+		{MainNetwork, 1978433, 12},
+		{MainNetwork, 2689608, 16},
+		{MainNetwork, 3200000, 16},
+		{TestNetwork, 1308737, 12},
+		{StageNetwork, 1151719, 15},
+		{StageNetwork, 1151720, 16},
+	}
+	for _, test := range tests {
+		got, err := HardForkVersion(test.network, test.height)
+		if err != nil {
+			t.Errorf("%d at %d: %s", test.network, test.height, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("%d at %d: want %d, got %d", test.network, test.height, test.want, got)
+		}
+	}
+	if _, err := HardForkVersion(1, 0); err == nil {
+		t.Errorf("unknown network: want error")
+	}
+	for _, network := range []int{MainNetwork, TestNetwork, StageNetwork} {
+		forks, _ := HardForks(network)
+		for i, fork := range forks {
+			if fork.Version() != uint8(i+1) || i > 0 && fork.Height() <= forks[i-1].Height() {
+				t.Errorf("%d: fork %d out of order", network, i)
+			}
+		}
+		if forks[len(forks)-1].Version() != MaxHardForkVersion {
+			t.Errorf("%d: want forks up to %d", network, MaxHardForkVersion)
+		}
+	}
+
+	header := &BlockHeader{majorVersion: 12}
+	if err := header.CheckHardForkVersion(MainNetwork, 2000000); err != nil {
+		t.Errorf("version 12 at 2000000: %s", err)
+	}
+	if err := header.CheckHardForkVersion(MainNetwork, 2210000); err == nil {
+		t.Errorf("version 12 at 2210000: want error")
+	}
+}
+
+func TestRulesForVersion(t *testing.T) {
+	tests := []struct {
+		version     uint8
+		minRingSize int
+		maxRingSize int
+		rctTypes    []uint8
+		viewTags    int
+		perByteFee  bool
+	}{
+		{1, 1, 0, nil, ViewTagsForbidden, false},
+		{4, 3, 0, []uint8{RCTTypeFull, RCTTypeSimple}, ViewTagsForbidden, false},
+		{7, 7, 0, []uint8{RCTTypeFull, RCTTypeSimple}, ViewTagsForbidden, false},
+		{8, 11, 11, []uint8{RCTTypeFull, RCTTypeSimple, RCTTypeBulletproof}, ViewTagsForbidden, true},
+		{13, 11, 11, []uint8{RCTTypeBulletproof2, RCTTypeCLSAG}, ViewTagsForbidden, true},
+		{15, 16, 16, []uint8{RCTTypeCLSAG, RCTTypeBulletproofPlus}, ViewTagsAllowed, true},
+		{16, 16, 16, []uint8{RCTTypeBulletproofPlus}, ViewTagsRequired, true},
+	}
+	for _, test := range tests {
+		rules, err := RulesForVersion(test.version)
+		if err != nil {
+			t.Errorf("%d: %s", test.version, err)
+			continue
+		}
+		if rules.MinRingSize != test.minRingSize || rules.MaxRingSize != test.maxRingSize {
+			t.Errorf("%d: ring size: want %d to %d, got %d to %d", test.version, test.minRingSize, test.maxRingSize, rules.MinRingSize, rules.MaxRingSize)
+		}
+		if fmt.Sprint(rules.RctTypes) != fmt.Sprint(test.rctTypes) {
+			t.Errorf("%d: RingCT types: want %v, got %v", test.version, test.rctTypes, rules.RctTypes)
+		}
+		if rules.ViewTags != test.viewTags {
+			t.Errorf("%d: view tags: want %d, got %d", test.version, test.viewTags, rules.ViewTags)
+		}
+		if rules.PerByteFee != test.perByteFee {
+			t.Errorf("%d: per byte fee: want %t, got %t", test.version, test.perByteFee, rules.PerByteFee)
+		}
+	}
+	rules, _ := RulesForVersion(16)
+	if !rules.AllowsRctType(RCTTypeBulletproofPlus) || rules.AllowsRctType(RCTTypeCLSAG) {
+		t.Errorf("16: want only bulletproof plus allowed")
+	}
+	if _, err := RulesForVersion(0); err == nil {
+		t.Errorf("0: want error")
+	}
+	if _, err := RulesForVersion(MaxHardForkVersion + 1); err == nil {
+		t.Errorf("%d: want error", MaxHardForkVersion+1)
+	}
+}
@@ -30,3 +30,29 @@ func RandomPubKey() (result *Key) {
 	p3.ToBytes(result)
 	return
 }
+
+// testReader is a deterministic randomness source: Keccak256 of a seed and
+// a counter, so tests can reproduce signatures exactly
+type testReader struct {
+	seed    []byte
+	counter uint64
+	buf     []byte
+}
+
+func newTestReader(seed string) *testReader {
+	return &testReader{seed: []byte(seed)}
+}
+
+func (r *testReader) Read(p []byte) (n int, err error) {
+	for n < len(p) {
+		if len(r.buf) == 0 {
+			h := Keccak256(r.seed, Uint64ToBytes(r.counter))
+			r.buf = h[:]
+			r.counter++
+		}
+		c := copy(p[n:], r.buf)
+		r.buf = r.buf[c:]
+		n += c
+	}
+	return
+}
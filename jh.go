@@ -0,0 +1,136 @@
+package moneroutil
+
+import (
+	"encoding/binary"
+)
+
+// JH-256, one of the CryptoNight finalizers, following the reference
+// implementation that works on 4 bit elements
+
+var jhSboxes = [2][16]byte{
+	{9, 0, 4, 11, 13, 12, 3, 15, 1, 10, 2, 6, 7, 5, 8, 14},
+	{3, 12, 6, 13, 5, 7, 1, 9, 15, 2, 0, 4, 11, 10, 14, 8},
+}
+
+// jhRoundConstantZero is the fractional part of the square root of 2
+var jhRoundConstantZero = [32]byte{
+	0x6a, 0x09, 0xe6, 0x67, 0xf3, 0xbc, 0xc9, 0x08, 0xb2, 0xfb, 0x13, 0x66, 0xea, 0x95, 0x7d, 0x3e,
+	0x3a, 0xde, 0xc1, 0x75, 0x12, 0x77, 0x50, 0x99, 0xda, 0x2f, 0x59, 0x0b, 0x06, 0x67, 0x32, 0x2a,
+}
+
+// jhLinear is the MDS code L applied to a pair of elements
+func jhLinear(a, b *byte) {
+	*b ^= (*a<<1 ^ *a>>3 ^ (*a>>2)&2) & 0xf
+	*a ^= (*b<<1 ^ *b>>3 ^ (*b>>2)&2) & 0xf
+}
+
+// jhPermute applies the permutation layer to d elements: swap the last two
+// of every four, put even elements in the first half and odd ones in the
+// second, then swap pairs in the second half
+func jhPermute(tem []byte, out []byte) {
+	n := len(tem)
+	for i := 0; i < n; i += 4 {
+		tem[i+2], tem[i+3] = tem[i+3], tem[i+2]
+	}
+	for i := 0; i < n/2; i++ {
+		out[i] = tem[2*i]
+		out[i+n/2] = tem[2*i+1]
+	}
+	for i := n / 2; i < n; i += 2 {
+		out[i], out[i+1] = out[i+1], out[i]
+	}
+}
+
+// jhRound is R8, where each bit of the round constant picks the S-box of
+// an element
+func jhRound(a *[256]byte, roundConstant *[64]byte) {
+	var tem [256]byte
+	for i := range tem {
+		bit := roundConstant[i>>2] >> uint(3-i&3) & 1
+		tem[i] = jhSboxes[bit][a[i]]
+	}
+	for i := 0; i < 256; i += 2 {
+		jhLinear(&tem[i], &tem[i+1])
+	}
+	jhPermute(tem[:], a[:])
+}
+
+// jhUpdateRoundConstant derives the next round constant with R6 and a zero
+// round constant
+func jhUpdateRoundConstant(roundConstant *[64]byte) {
+	var tem [64]byte
+	for i := range tem {
+		tem[i] = jhSboxes[0][roundConstant[i]]
+	}
+	for i := 0; i < 64; i += 2 {
+		jhLinear(&tem[i], &tem[i+1])
+	}
+	jhPermute(tem[:], roundConstant[:])
+}
+
+// jhE8 is the bijective function of JH on the 1024 bit state
+func jhE8(h *[128]byte) {
+	var roundConstant [64]byte
+	for i := range roundConstant {
+		roundConstant[i] = jhRoundConstantZero[i/2] >> uint(4*(1-i%2)) & 0xf
+	}
+	// group bits i, i+256, i+512 and i+768 into element i
+	var tem, a [256]byte
+	for i := 0; i < 256; i++ {
+		for k := 0; k < 4; k++ {
+			j := i + 256*k
+			tem[i] |= (h[j>>3] >> uint(7-j&7) & 1) << uint(3-k)
+		}
+	}
+	for i := 0; i < 128; i++ {
+		a[2*i] = tem[i]
+		a[2*i+1] = tem[i+128]
+	}
+	for round := 0; round < 42; round++ {
+		jhRound(&a, &roundConstant)
+		jhUpdateRoundConstant(&roundConstant)
+	}
+	for i := 0; i < 128; i++ {
+		tem[i] = a[2*i]
+		tem[i+128] = a[2*i+1]
+	}
+	*h = [128]byte{}
+	for i := 0; i < 256; i++ {
+		for k := 0; k < 4; k++ {
+			j := i + 256*k
+			h[j>>3] |= (tem[i] >> uint(3-k) & 1) << uint(7-j&7)
+		}
+	}
+}
+
+func jhCompress(h *[128]byte, block []byte) {
+	for i := 0; i < 64; i++ {
+		h[i] ^= block[i]
+	}
+	jhE8(h)
+	for i := 0; i < 64; i++ {
+		h[i+64] ^= block[i]
+	}
+}
+
+func jh256(data []byte) (result Hash) {
+	var h [128]byte
+	// the initial value is the compression of the output length in bits
+	h[0], h[1] = 1, 0
+	jhCompress(&h, make([]byte, 64))
+	// the padding is a one bit and zeros up to a block boundary, plus a
+	// block ending in the 128 bit length when the message was not aligned
+	nBlocks := len(data)/64 + 1
+	if len(data)%64 != 0 {
+		nBlocks++
+	}
+	padded := make([]byte, 64*nBlocks)
+	copy(padded, data)
+	padded[len(data)] = 0x80
+	binary.BigEndian.PutUint64(padded[len(padded)-8:], uint64(len(data))*8)
+	for i := 0; i < len(padded); i += 64 {
+		jhCompress(&h, padded[i:i+64])
+	}
+	copy(result[:], h[96:])
+	return
+}
@@ -0,0 +1,691 @@
+package moneroutil
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// The JSON encodings follow monerod's as_json output, so transactions can
+// be compared with block explorers and decoded back to the same binary.
+// Keys and hashes are hex strings, byte blobs such as extra are arrays of
+// numbers and variants are objects keyed by the variant name.
+
+// jsonKey is a public key or scalar of a transaction, written as hex
+type jsonKey Key
+
+func (p jsonKey) MarshalText() ([]byte, error) {
+	return []byte(hex.EncodeToString(p[:])), nil
+}
+
+func (p *jsonKey) UnmarshalText(text []byte) (err error) {
+	b, err := decodeHexLength(string(text), KeyLength)
+	if err != nil {
+		return
+	}
+	copy(p[:], b)
+	return
+}
+
+// MarshalText encodes the hash as hex
+func (h Hash) MarshalText() ([]byte, error) {
+	return []byte(hex.EncodeToString(h[:])), nil
+}
+
+func (h *Hash) UnmarshalText(text []byte) (err error) {
+	b, err := decodeHexLength(string(text), HashLength)
+	if err != nil {
+		return
+	}
+	copy(h[:], b)
+	return
+}
+
+// decodeHexLength decodes s, which must hold exactly length bytes
+func decodeHexLength(s string, length int) (result []byte, err error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return
+	}
+	if len(b) != length {
+		err = fmt.Errorf("Want %d bytes of hex, got %d", length, len(b))
+		return
+	}
+	result = b
+	return
+}
+
+// jsonBytes is a byte blob written as an array of numbers
+type jsonBytes []byte
+
+func (b jsonBytes) MarshalJSON() ([]byte, error) {
+	values := make([]int, len(b))
+	for i, v := range b {
+		values[i] = int(v)
+	}
+	return json.Marshal(values)
+}
+
+func (b *jsonBytes) UnmarshalJSON(data []byte) (err error) {
+	var values []int
+	if err = json.Unmarshal(data, &values); err != nil {
+		return
+	}
+	result := make([]byte, len(values))
+	for i, v := range values {
+		if v < 0 || v > 0xff {
+			err = fmt.Errorf("Byte value %d out of range", v)
+			return
+		}
+		result[i] = byte(v)
+	}
+	*b = result
+	return
+}
+
+type txInGenJSON struct {
+	Height uint64 `json:"height"`
+}
+
+type txInToScriptJSON struct {
+	Prev    Hash      `json:"prev"`
+	Prevout uint64    `json:"prevout"`
+	Sigset  jsonBytes `json:"sigset"`
+}
+
+type txInToScriptHashJSON struct {
+	Prev    Hash              `json:"prev"`
+	Prevout uint64            `json:"prevout"`
+	Script  txOutToScriptJSON `json:"script"`
+	Sigset  jsonBytes         `json:"sigset"`
+}
+
+type txInToKeyJSON struct {
+	Amount     uint64   `json:"amount"`
+	KeyOffsets []uint64 `json:"key_offsets"`
+	KeyImage   jsonKey  `json:"k_image"`
+}
+
+// txInJSON holds exactly one of the input variants
+type txInJSON struct {
+	Gen        *txInGenJSON          `json:"gen,omitempty"`
+	Script     *txInToScriptJSON     `json:"script,omitempty"`
+	ScriptHash *txInToScriptHashJSON `json:"scripthash,omitempty"`
+	Key        *txInToKeyJSON        `json:"key,omitempty"`
+}
+
+type txOutToScriptJSON struct {
+	Keys   []jsonKey `json:"keys"`
+	Script jsonBytes `json:"script"`
+}
+
+type txOutToScriptHashJSON struct {
+	Hash Hash `json:"hash"`
+}
+
+type txOutToTaggedKeyJSON struct {
+	Key     jsonKey `json:"key"`
+	ViewTag string  `json:"view_tag"`
+}
+
+// txOutTargetJSON holds exactly one of the output variants
+type txOutTargetJSON struct {
+	Script     *txOutToScriptJSON     `json:"script,omitempty"`
+	ScriptHash *txOutToScriptHashJSON `json:"scripthash,omitempty"`
+	Key        *jsonKey               `json:"key,omitempty"`
+	TaggedKey  *txOutToTaggedKeyJSON  `json:"tagged_key,omitempty"`
+}
+
+type txOutJSON struct {
+	Amount uint64          `json:"amount"`
+	Target txOutTargetJSON `json:"target"`
+}
+
+type transactionPrefixJSON struct {
+	Version    uint32     `json:"version"`
+	UnlockTime uint64     `json:"unlock_time"`
+	Vin        []txInJSON `json:"vin"`
+	Vout       []*TxOut   `json:"vout"`
+	Extra      jsonBytes  `json:"extra"`
+}
+
+type ecdhTupleJSON struct {
+	Mask   *jsonKey `json:"mask,omitempty"`
+	Amount string   `json:"amount"`
+}
+
+type rctSigBaseJSON struct {
+	Type       uint8           `json:"type"`
+	TxnFee     *uint64         `json:"txnFee,omitempty"`
+	PseudoOuts []jsonKey       `json:"pseudoOuts,omitempty"`
+	EcdhInfo   []ecdhTupleJSON `json:"ecdhInfo,omitempty"`
+	OutPk      []jsonKey       `json:"outPk,omitempty"`
+}
+
+type rctSigPrunableJSON struct {
+	Nbp        *uint64           `json:"nbp,omitempty"`
+	RangeSigs  []RangeSig        `json:"rangeSigs,omitempty"`
+	Bp         []Bulletproof     `json:"bp,omitempty"`
+	Bpp        []BulletproofPlus `json:"bpp,omitempty"`
+	MGs        []MlsagSig        `json:"MGs,omitempty"`
+	CLSAGs     []ClsagSig        `json:"CLSAGs,omitempty"`
+	PseudoOuts []jsonKey         `json:"pseudoOuts,omitempty"`
+}
+
+// rctSigJSON is how monerod splits a RingCT signature within a transaction
+type rctSigJSON struct {
+	RctSignatures  *rctSigBaseJSON     `json:"rct_signatures,omitempty"`
+	RctsigPrunable *rctSigPrunableJSON `json:"rctsig_prunable,omitempty"`
+}
+
+type transactionJSON struct {
+	transactionPrefixJSON
+	Signatures *[]string `json:"signatures,omitempty"`
+	rctSigJSON
+}
+
+type rangeSigJSON struct {
+	Asig string `json:"asig"`
+	Ci   string `json:"Ci"`
+}
+
+type mlsagSigJSON struct {
+	Ss [][]jsonKey `json:"ss"`
+	Cc jsonKey     `json:"cc"`
+}
+
+type clsagSigJSON struct {
+	S  []jsonKey `json:"s"`
+	C1 jsonKey   `json:"c1"`
+	D  jsonKey   `json:"D"`
+}
+
+type bulletproofJSON struct {
+	A    jsonKey   `json:"A"`
+	S    jsonKey   `json:"S"`
+	T1   jsonKey   `json:"T1"`
+	T2   jsonKey   `json:"T2"`
+	Taux jsonKey   `json:"taux"`
+	Mu   jsonKey   `json:"mu"`
+	L    []jsonKey `json:"L"`
+	R    []jsonKey `json:"R"`
+	A2   jsonKey   `json:"a"`
+	B    jsonKey   `json:"b"`
+	T    jsonKey   `json:"t"`
+}
+
+type bulletproofPlusJSON struct {
+	A  jsonKey   `json:"A"`
+	A1 jsonKey   `json:"A1"`
+	B  jsonKey   `json:"B"`
+	R1 jsonKey   `json:"r1"`
+	S1 jsonKey   `json:"s1"`
+	D1 jsonKey   `json:"d1"`
+	L  []jsonKey `json:"L"`
+	R  []jsonKey `json:"R"`
+}
+
+// toJSONKeys copies keys into a slice that encodes as [] when empty
+func toJSONKeys(keys []Key) (result []jsonKey) {
+	result = make([]jsonKey, len(keys))
+	for i := range keys {
+		result[i] = jsonKey(keys[i])
+	}
+	return
+}
+
+func fromJSONKeys(keys []jsonKey) (result []Key) {
+	if keys == nil {
+		return
+	}
+	result = make([]Key, len(keys))
+	for i := range keys {
+		result[i] = Key(keys[i])
+	}
+	return
+}
+
+func txInToJSON(txIn TxInSerializer) (result txInJSON) {
+	switch t := txIn.(type) {
+	case *TxInGen:
+		result.Gen = &txInGenJSON{Height: t.height}
+	case *TxInToScript:
+		result.Script = &txInToScriptJSON{Prev: t.prev, Prevout: t.prevout, Sigset: t.sigset}
+	case *TxInToScriptHash:
+		result.ScriptHash = &txInToScriptHashJSON{
+			Prev:    t.prev,
+			Prevout: t.prevout,
+			Script:  txOutToScriptJSON{Keys: toJSONKeys(t.script.keys), Script: t.script.script},
+			Sigset:  t.sigset,
+		}
+	case *TxInToKey:
+		result.Key = &txInToKeyJSON{
+			Amount:     t.amount,
+			KeyOffsets: append([]uint64{}, t.keyOffsets...),
+			KeyImage:   jsonKey(t.keyImage),
+		}
+	}
+	return
+}
+
+func (j *txInJSON) txIn() (result TxInSerializer, err error) {
+	switch {
+	case j.Gen != nil:
+		result = &TxInGen{height: j.Gen.Height}
+	case j.Script != nil:
+		result = &TxInToScript{prev: j.Script.Prev, prevout: j.Script.Prevout, sigset: j.Script.Sigset}
+	case j.ScriptHash != nil:
+		result = &TxInToScriptHash{
+			prev:    j.ScriptHash.Prev,
+			prevout: j.ScriptHash.Prevout,
+			script:  TxOutToScript{keys: fromJSONKeys(j.ScriptHash.Script.Keys), script: j.ScriptHash.Script.Script},
+			sigset:  j.ScriptHash.Sigset,
+		}
+	case j.Key != nil:
+		result = &TxInToKey{amount: j.Key.Amount, keyOffsets: j.Key.KeyOffsets, keyImage: Key(j.Key.KeyImage)}
+	default:
+		err = fmt.Errorf("Unknown TxIn variant")
+	}
+	return
+}
+
+func (t *TxOut) MarshalJSON() ([]byte, error) {
+	j := txOutJSON{Amount: t.amount}
+	switch {
+	case t.script != nil:
+		j.Target.Script = &txOutToScriptJSON{Keys: toJSONKeys(t.script.keys), Script: t.script.script}
+	case t.scriptHash != nil:
+		j.Target.ScriptHash = &txOutToScriptHashJSON{Hash: t.scriptHash.hash}
+	case t.tagged:
+		j.Target.TaggedKey = &txOutToTaggedKeyJSON{Key: jsonKey(t.key), ViewTag: hex.EncodeToString([]byte{t.viewTag})}
+	default:
+		key := jsonKey(t.key)
+		j.Target.Key = &key
+	}
+	return json.Marshal(j)
+}
+
+func (t *TxOut) UnmarshalJSON(data []byte) (err error) {
+	var j txOutJSON
+	if err = json.Unmarshal(data, &j); err != nil {
+		return
+	}
+	result := TxOut{amount: j.Amount}
+	switch target := j.Target; {
+	case target.Script != nil:
+		result.script = &TxOutToScript{keys: fromJSONKeys(target.Script.Keys), script: target.Script.Script}
+	case target.ScriptHash != nil:
+		result.scriptHash = &TxOutToScriptHash{hash: target.ScriptHash.Hash}
+	case target.TaggedKey != nil:
+		var viewTag []byte
+		if viewTag, err = decodeHexLength(target.TaggedKey.ViewTag, 1); err != nil {
+			return
+		}
+		result.key, result.tagged, result.viewTag = Key(target.TaggedKey.Key), true, viewTag[0]
+	case target.Key != nil:
+		result.key = Key(*target.Key)
+	default:
+		err = fmt.Errorf("Unknown TxOut variant")
+		return
+	}
+	*t = result
+	return
+}
+
+func (t *TransactionPrefix) toJSON() (result transactionPrefixJSON) {
+	result = transactionPrefixJSON{
+		Version:    t.version,
+		UnlockTime: t.unlockTime,
+		Vin:        make([]txInJSON, len(t.vin)),
+		Vout:       append([]*TxOut{}, t.vout...),
+		Extra:      t.extra,
+	}
+	for i, txIn := range t.vin {
+		result.Vin[i] = txInToJSON(txIn)
+	}
+	return
+}
+
+func (t *TransactionPrefix) fromJSON(j *transactionPrefixJSON) (err error) {
+	vin := make([]TxInSerializer, len(j.Vin))
+	for i := range j.Vin {
+		if vin[i], err = j.Vin[i].txIn(); err != nil {
+			return
+		}
+	}
+	for i, txOut := range j.Vout {
+		if txOut == nil {
+			err = fmt.Errorf("Output %d is null", i)
+			return
+		}
+	}
+	*t = TransactionPrefix{
+		version:    j.Version,
+		unlockTime: j.UnlockTime,
+		vin:        vin,
+		vout:       j.Vout,
+		extra:      j.Extra,
+	}
+	if t.extra == nil {
+		t.extra = []byte{}
+	}
+	return
+}
+
+func (t *TransactionPrefix) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.toJSON())
+}
+
+func (t *TransactionPrefix) UnmarshalJSON(data []byte) (err error) {
+	var j transactionPrefixJSON
+	if err = json.Unmarshal(data, &j); err != nil {
+		return
+	}
+	err = t.fromJSON(&j)
+	return
+}
+
+func (r *RangeSig) MarshalJSON() ([]byte, error) {
+	return json.Marshal(rangeSigJSON{
+		Asig: hex.EncodeToString(r.asig.Serialize()),
+		Ci:   hex.EncodeToString(r.ci.Serialize()),
+	})
+}
+
+func (r *RangeSig) UnmarshalJSON(data []byte) (err error) {
+	var j rangeSigJSON
+	if err = json.Unmarshal(data, &j); err != nil {
+		return
+	}
+	asig, err := decodeHexLength(j.Asig, 129*KeyLength)
+	if err != nil {
+		return
+	}
+	ci, err := decodeHexLength(j.Ci, 64*KeyLength)
+	if err != nil {
+		return
+	}
+	if r.asig, err = ParseBoroSig(bytes.NewReader(asig)); err != nil {
+		return
+	}
+	r.ci, err = ParseKey64(bytes.NewReader(ci))
+	return
+}
+
+// MarshalJSON writes the responses and challenge. The key images come
+// from the inputs and are left out.
+func (m *MlsagSig) MarshalJSON() ([]byte, error) {
+	j := mlsagSigJSON{Ss: make([][]jsonKey, len(m.ss)), Cc: jsonKey(m.cc)}
+	for i := range m.ss {
+		j.Ss[i] = toJSONKeys(m.ss[i])
+	}
+	return json.Marshal(j)
+}
+
+func (m *MlsagSig) UnmarshalJSON(data []byte) (err error) {
+	var j mlsagSigJSON
+	if err = json.Unmarshal(data, &j); err != nil {
+		return
+	}
+	ss := make([][]Key, len(j.Ss))
+	for i := range j.Ss {
+		ss[i] = fromJSONKeys(j.Ss[i])
+	}
+	*m = MlsagSig{ss: ss, cc: Key(j.Cc)}
+	return
+}
+
+// MarshalJSON writes the responses, c1 and D. The key image comes from the
+// input and is left out.
+func (c *ClsagSig) MarshalJSON() ([]byte, error) {
+	return json.Marshal(clsagSigJSON{S: toJSONKeys(c.s), C1: jsonKey(c.c1), D: jsonKey(c.d)})
+}
+
+func (c *ClsagSig) UnmarshalJSON(data []byte) (err error) {
+	var j clsagSigJSON
+	if err = json.Unmarshal(data, &j); err != nil {
+		return
+	}
+	*c = ClsagSig{s: fromJSONKeys(j.S), c1: Key(j.C1), d: Key(j.D)}
+	return
+}
+
+func (b *Bulletproof) MarshalJSON() ([]byte, error) {
+	return json.Marshal(bulletproofJSON{
+		A: jsonKey(b.a), S: jsonKey(b.s), T1: jsonKey(b.t1), T2: jsonKey(b.t2), Taux: jsonKey(b.taux), Mu: jsonKey(b.mu),
+		L: toJSONKeys(b.l), R: toJSONKeys(b.r),
+		A2: jsonKey(b.innerA), B: jsonKey(b.innerB), T: jsonKey(b.t),
+	})
+}
+
+func (b *Bulletproof) UnmarshalJSON(data []byte) (err error) {
+	var j bulletproofJSON
+	if err = json.Unmarshal(data, &j); err != nil {
+		return
+	}
+	*b = Bulletproof{
+		a: Key(j.A), s: Key(j.S), t1: Key(j.T1), t2: Key(j.T2), taux: Key(j.Taux), mu: Key(j.Mu),
+		l: fromJSONKeys(j.L), r: fromJSONKeys(j.R),
+		innerA: Key(j.A2), innerB: Key(j.B), t: Key(j.T),
+	}
+	return
+}
+
+func (b *BulletproofPlus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(bulletproofPlusJSON{
+		A: jsonKey(b.a), A1: jsonKey(b.a1), B: jsonKey(b.b), R1: jsonKey(b.r1), S1: jsonKey(b.s1), D1: jsonKey(b.d1),
+		L: toJSONKeys(b.l), R: toJSONKeys(b.r),
+	})
+}
+
+func (b *BulletproofPlus) UnmarshalJSON(data []byte) (err error) {
+	var j bulletproofPlusJSON
+	if err = json.Unmarshal(data, &j); err != nil {
+		return
+	}
+	*b = BulletproofPlus{
+		a: Key(j.A), a1: Key(j.A1), b: Key(j.B), r1: Key(j.R1), s1: Key(j.S1), d1: Key(j.D1),
+		l: fromJSONKeys(j.L), r: fromJSONKeys(j.R),
+	}
+	return
+}
+
+// toJSON splits the signature into its base and, unless it is pruned or of
+// type null, its prunable part
+func (r *RctSig) toJSON(pruned bool) (result rctSigJSON) {
+	base := &rctSigBaseJSON{Type: r.sigType}
+	result.RctSignatures = base
+	if r.sigType == RCTTypeNull {
+		return
+	}
+	fee := r.txFee
+	base.TxnFee = &fee
+	if r.sigType == RCTTypeSimple {
+		base.PseudoOuts = toJSONKeys(r.pseudoOuts)
+	}
+	base.EcdhInfo = make([]ecdhTupleJSON, len(r.ecdhInfo))
+	for i, ecdh := range r.ecdhInfo {
+		if r.compactAmounts() {
+			base.EcdhInfo[i].Amount = hex.EncodeToString(ecdh.amount[:8])
+			continue
+		}
+		mask := jsonKey(ecdh.mask)
+		base.EcdhInfo[i].Mask = &mask
+		base.EcdhInfo[i].Amount = hex.EncodeToString(ecdh.amount[:])
+	}
+	base.OutPk = make([]jsonKey, len(r.outPk))
+	for i, ctKey := range r.outPk {
+		base.OutPk[i] = jsonKey(ctKey.mask)
+	}
+	if pruned {
+		return
+	}
+
+	prunable := new(rctSigPrunableJSON)
+	result.RctsigPrunable = prunable
+	switch r.sigType {
+	case RCTTypeFull, RCTTypeSimple:
+		prunable.RangeSigs = append([]RangeSig{}, r.rangeSigs...)
+	case RCTTypeBulletproofPlus:
+		nbp := uint64(len(r.bulletproofsPlus))
+		prunable.Nbp = &nbp
+		prunable.Bpp = append([]BulletproofPlus{}, r.bulletproofsPlus...)
+	default:
+		nbp := uint64(len(r.bulletproofs))
+		prunable.Nbp = &nbp
+		prunable.Bp = append([]Bulletproof{}, r.bulletproofs...)
+	}
+	if r.usesClsag() {
+		prunable.CLSAGs = append([]ClsagSig{}, r.clsagSigs...)
+	} else {
+		prunable.MGs = append([]MlsagSig{}, r.mlsagSigs...)
+	}
+	if r.sigType >= RCTTypeBulletproof {
+		prunable.PseudoOuts = toJSONKeys(r.pseudoOuts)
+	}
+	return
+}
+
+// fromJSON rebuilds the signature from its base and, when it was not
+// pruned, its prunable part
+func (r *RctSig) fromJSON(j *rctSigJSON) (err error) {
+	base := j.RctSignatures
+	if base == nil {
+		err = fmt.Errorf("Missing rct_signatures")
+		return
+	}
+	result := RctSig{}
+	result.sigType = base.Type
+	if result.sigType > RCTTypeBulletproofPlus {
+		err = fmt.Errorf("Bad sigType %d", result.sigType)
+		return
+	}
+	if result.sigType == RCTTypeNull {
+		*r = result
+		return
+	}
+	if base.TxnFee != nil {
+		result.txFee = *base.TxnFee
+	}
+	if result.sigType == RCTTypeSimple {
+		result.pseudoOuts = fromJSONKeys(base.PseudoOuts)
+	}
+	result.ecdhInfo = make([]EcdhTuple, len(base.EcdhInfo))
+	for i, ecdh := range base.EcdhInfo {
+		amountLength := KeyLength
+		if result.compactAmounts() {
+			amountLength = 8
+		} else if ecdh.Mask != nil {
+			result.ecdhInfo[i].mask = Key(*ecdh.Mask)
+		}
+		var amount []byte
+		if amount, err = decodeHexLength(ecdh.Amount, amountLength); err != nil {
+			return
+		}
+		copy(result.ecdhInfo[i].amount[:], amount)
+	}
+	result.outPk = make([]CtKey, len(base.OutPk))
+	for i, mask := range base.OutPk {
+		result.outPk[i].mask = Key(mask)
+	}
+
+	if prunable := j.RctsigPrunable; prunable != nil {
+		result.rangeSigs = prunable.RangeSigs
+		result.mlsagSigs = prunable.MGs
+		result.clsagSigs = prunable.CLSAGs
+		if result.sigType >= RCTTypeBulletproof {
+			result.bulletproofs = prunable.Bp
+			result.bulletproofsPlus = prunable.Bpp
+			result.pseudoOuts = fromJSONKeys(prunable.PseudoOuts)
+			nProofs := len(prunable.Bp) + len(prunable.Bpp)
+			if prunable.Nbp == nil || *prunable.Nbp != uint64(nProofs) {
+				err = fmt.Errorf("Bad nbp for %d range proofs", nProofs)
+				return
+			}
+		}
+	}
+	*r = result
+	return
+}
+
+// MarshalJSON writes the signature as the rct_signatures and
+// rctsig_prunable members of a monerod transaction
+func (r *RctSig) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.toJSON(false))
+}
+
+func (r *RctSig) UnmarshalJSON(data []byte) (err error) {
+	var j rctSigJSON
+	if err = json.Unmarshal(data, &j); err != nil {
+		return
+	}
+	err = r.fromJSON(&j)
+	return
+}
+
+// MarshalJSON writes the transaction as monerod's as_json does. Pruned
+// transactions leave out the signatures and the prunable RingCT part.
+func (t *Transaction) MarshalJSON() ([]byte, error) {
+	j := transactionJSON{transactionPrefixJSON: t.toJSON()}
+	if t.version == 1 {
+		if !t.pruned {
+			signatures := make([]string, len(t.signatures))
+			for i := range t.signatures {
+				signatures[i] = hex.EncodeToString(t.signatures[i].Serialize())
+			}
+			j.Signatures = &signatures
+		}
+	} else if t.rctSignature != nil {
+		j.rctSigJSON = t.rctSignature.toJSON(t.pruned)
+	}
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON reads a transaction written by monerod or MarshalJSON. A
+// transaction without its signatures or prunable part is marked pruned.
+func (t *Transaction) UnmarshalJSON(data []byte) (err error) {
+	var j transactionJSON
+	if err = json.Unmarshal(data, &j); err != nil {
+		return
+	}
+	result := Transaction{}
+	if err = result.TransactionPrefix.fromJSON(&j.transactionPrefixJSON); err != nil {
+		return
+	}
+	if result.version == 1 {
+		if j.Signatures == nil {
+			result.pruned = true
+		}
+		var signatures []string
+		if j.Signatures != nil {
+			signatures = *j.Signatures
+		}
+		for i, signature := range signatures {
+			var b []byte
+			if b, err = hex.DecodeString(signature); err != nil {
+				return
+			}
+			if len(b)%(2*KeyLength) != 0 {
+				err = fmt.Errorf("Signatures of input %d have bad length %d", i, len(b))
+				return
+			}
+			buf := bytes.NewReader(b)
+			ringSignature := make(RingSignature, len(b)/(2*KeyLength))
+			for k := range ringSignature {
+				if ringSignature[k], err = ParseSignature(buf); err != nil {
+					return
+				}
+			}
+			result.signatures = append(result.signatures, ringSignature)
+		}
+	} else {
+		result.rctSignature = new(RctSig)
+		if err = result.rctSignature.fromJSON(&j.rctSigJSON); err != nil {
+			return
+		}
+		result.pruned = result.rctSignature.sigType != RCTTypeNull && j.RctsigPrunable == nil
+	}
+	*t = result
+	return
+}
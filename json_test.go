@@ -0,0 +1,115 @@
+package moneroutil
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+)
+
+func TestTransactionJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		txHex    string
+		wantJSON string
+	}{
+		{
+			name:  "genesis",
+			txHex: "013c01ff0001ffffffffffff03029b2e4c0281c0b02e7c53291a94d1d0cbff8883f8024f5142ee494ffbbd08807121017767aafcde9be00dcfd098715ebcf7f410daebc582fda69d24a28e9d0bc890d1",
+			wantJSON: `{
+  "version": 1,
+  "unlock_time": 60,
+  "vin": [ {
+      "gen": {
+        "height": 0
+      }
+    }
+  ],
+  "vout": [ {
+      "amount": 17592186044415,
+      "target": {
+        "key": "9b2e4c0281c0b02e7c53291a94d1d0cbff8883f8024f5142ee494ffbbd088071"
+      }
+    }
+  ],
+  "extra": [ 1, 119, 103, 170, 252, 222, 155, 224, 13, 207, 208, 152, 113, 94, 188, 247, 244, 16, 218, 235, 197, 130, 253, 166, 157, 36, 162, 142, 157, 11, 200, 144, 209
+  ],
+  "signatures": [ ]
+}`,
+		},
+	}
+	for _, test := range tests {
+		serializedTx, _ := hex.DecodeString(test.txHex)
+		transaction, err := ParseTransaction(bytes.NewReader(serializedTx))
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+			continue
+		}
+		got, err := json.Marshal(transaction)
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+			continue
+		}
+		want := new(bytes.Buffer)
+		json.Compact(want, []byte(test.wantJSON))
+		if bytes.Compare(want.Bytes(), got) != 0 {
+			t.Errorf("%s: want %s, got %s", test.name, want, got)
+		}
+		decoded := new(Transaction)
+		if err = json.Unmarshal([]byte(test.wantJSON), decoded); err != nil {
+			t.Errorf("%s: %s", test.name, err)
+			continue
+		}
+		if gotSerialized := decoded.Serialize(); bytes.Compare(serializedTx, gotSerialized) != 0 {
+			t.Errorf("%s: want %x, got %x", test.name, serializedTx, gotSerialized)
+		}
+	}
+}
+
+func TestTxOutJSON(t *testing.T) {
+	key := HexToKey("9b2e4c0281c0b02e7c53291a94d1d0cbff8883f8024f5142ee494ffbbd088071")
+	tests := []struct {
+		name     string
+		txOut    *TxOut
+		wantJSON string
+	}{
+		{
+			name:     "key",
+			txOut:    NewTxOut(5, key),
+			wantJSON: `{"amount":5,"target":{"key":"9b2e4c0281c0b02e7c53291a94d1d0cbff8883f8024f5142ee494ffbbd088071"}}`,
+		},
+		{
+			name:     "tagged key",
+			txOut:    NewTaggedTxOut(0, key, 0x5b),
+			wantJSON: `{"amount":0,"target":{"tagged_key":{"key":"9b2e4c0281c0b02e7c53291a94d1d0cbff8883f8024f5142ee494ffbbd088071","view_tag":"5b"}}}`,
+		},
+	}
+	for _, test := range tests {
+		got, err := json.Marshal(test.txOut)
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+			continue
+		}
+		if string(got) != test.wantJSON {
+			t.Errorf("%s: want %s, got %s", test.name, test.wantJSON, got)
+		}
+		decoded := new(TxOut)
+		if err = json.Unmarshal(got, decoded); err != nil {
+			t.Errorf("%s: %s", test.name, err)
+			continue
+		}
+		if bytes.Compare(test.txOut.Serialize(), decoded.Serialize()) != 0 {
+			t.Errorf("%s: want %x, got %x", test.name, test.txOut.Serialize(), decoded.Serialize())
+		}
+	}
+
+	for _, bad := range []string{
+		`{"amount":1,"target":{}}`,
+		`{"amount":1,"target":{"key":"9b2e"}}`,
+		`{"amount":1,"target":{"tagged_key":{"key":"9b2e4c0281c0b02e7c53291a94d1d0cbff8883f8024f5142ee494ffbbd088071","view_tag":"5b5b"}}}`,
+	} {
+		if err := json.Unmarshal([]byte(bad), new(TxOut)); err == nil {
+			t.Errorf("%s: want error", bad)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package moneroutil
 
 import (
+	"encoding/binary"
+
 	"github.com/ebfe/keccak"
 )
 
@@ -37,3 +39,73 @@ func Keccak512(data ...[]byte) (result Hash) {
 	copy(result[:], r)
 	return
 }
+
+var keccakRoundConstants = [24]uint64{
+	0x0000000000000001, 0x0000000000008082, 0x800000000000808a, 0x8000000080008000,
+	0x000000000000808b, 0x0000000080000001, 0x8000000080008081, 0x8000000000008009,
+	0x000000000000008a, 0x0000000000000088, 0x0000000080008009, 0x000000008000000a,
+	0x000000008000808b, 0x800000000000008b, 0x8000000000008089, 0x8000000000008003,
+	0x8000000000008002, 0x8000000000000080, 0x000000000000800a, 0x800000008000000a,
+	0x8000000080008081, 0x8000000000008080, 0x0000000080000001, 0x8000000080008008,
+}
+
+var keccakRotations = [24]uint{1, 3, 6, 10, 15, 21, 28, 36, 45, 55, 2, 14, 27, 41, 56, 8, 25, 43, 62, 18, 39, 61, 20, 44}
+
+var keccakLanes = [24]int{10, 7, 11, 17, 18, 3, 5, 16, 8, 21, 24, 4, 15, 23, 19, 13, 12, 2, 20, 14, 22, 9, 6, 1}
+
+// keccakF1600 is the Keccak permutation
+func keccakF1600(st *[25]uint64) {
+	var bc [5]uint64
+	for round := 0; round < 24; round++ {
+		// theta
+		for i := 0; i < 5; i++ {
+			bc[i] = st[i] ^ st[i+5] ^ st[i+10] ^ st[i+15] ^ st[i+20]
+		}
+		for i := 0; i < 5; i++ {
+			t := bc[(i+4)%5] ^ (bc[(i+1)%5]<<1 | bc[(i+1)%5]>>63)
+			for j := 0; j < 25; j += 5 {
+				st[j+i] ^= t
+			}
+		}
+		// rho and pi
+		t := st[1]
+		for i := 0; i < 24; i++ {
+			j := keccakLanes[i]
+			bc[0] = st[j]
+			st[j] = t<<keccakRotations[i] | t>>(64-keccakRotations[i])
+			t = bc[0]
+		}
+		// chi
+		for j := 0; j < 25; j += 5 {
+			for i := 0; i < 5; i++ {
+				bc[i] = st[j+i]
+			}
+			for i := 0; i < 5; i++ {
+				st[j+i] ^= ^bc[(i+1)%5] & bc[(i+2)%5]
+			}
+		}
+		// iota
+		st[0] ^= keccakRoundConstants[round]
+	}
+}
+
+// keccak1600 absorbs data with the rate of Keccak256 and returns the whole
+// state (Monero's hash_process)
+func keccak1600(data []byte) (st [25]uint64) {
+	const rate = 136
+	for ; len(data) >= rate; data = data[rate:] {
+		for i := 0; i < rate/8; i++ {
+			st[i] ^= binary.LittleEndian.Uint64(data[8*i:])
+		}
+		keccakF1600(&st)
+	}
+	block := make([]byte, rate)
+	copy(block, data)
+	block[len(data)] = 1
+	block[rate-1] |= 0x80
+	for i := 0; i < rate/8; i++ {
+		st[i] ^= binary.LittleEndian.Uint64(block[8*i:])
+	}
+	keccakF1600(&st)
+	return
+}
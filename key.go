@@ -2,7 +2,10 @@ package moneroutil
 
 import (
 	"crypto/rand"
+	"encoding/binary"
+	"fmt"
 	"io"
+	"math"
 )
 
 const (
@@ -31,32 +34,96 @@ func (p *Key) PubKey() (pubKey *Key) {
 
 // Creates a point on the Edwards Curve by hashing the key
 func (p *Key) HashToEC() (result *ExtendedGroupElement) {
+	result = HashToPoint(p[:])
+	return
+}
+
+// FieldElementToPoint interprets b as a field element and maps it onto the
+// curve (Monero's ge_fromfe_frombytes_vartime). The result is not multiplied
+// by the cofactor, so it is not necessarily in the prime order subgroup.
+// It runs in variable time, so b must be public, as keys and hashes are.
+func FieldElementToPoint(b *Key) (result *ProjectiveGroupElement) {
+	result = new(ProjectiveGroupElement)
+	result.FromBytes(b)
+	return
+}
+
+// HashToPoint hashes data with Keccak256, maps the hash onto the curve and
+// multiplies by 8, giving a point in the prime order subgroup with no known
+// discrete log relative to G (Monero's hash_to_ec). It is variable-time, as
+// FieldElementToPoint, and only meant for public data.
+func HashToPoint(data ...[]byte) (result *ExtendedGroupElement) {
 	result = new(ExtendedGroupElement)
-	var p1 ProjectiveGroupElement
 	var p2 CompletedGroupElement
-	h := Key(Keccak256(p[:]))
-	p1.FromBytes(&h)
-	GeMul8(&p2, &p1)
+	h := Key(Keccak256(data...))
+	GeMul8(&p2, FieldElementToPoint(&h))
 	p2.ToExtended(result)
 	return
 }
 
-func RandomScalar() (result *Key) {
-	result = new(Key)
+// RandomScalarFrom reads 64 bytes from rand and reduces them mod l, so the
+// result is uniform over the scalars. It fails if rand runs out of entropy.
+func RandomScalarFrom(rand io.Reader) (result *Key, err error) {
 	var reduceFrom [KeyLength * 2]byte
-	tmp := make([]byte, KeyLength*2)
-	rand.Read(tmp)
-	copy(reduceFrom[:], tmp)
+	if _, err = io.ReadFull(rand, reduceFrom[:]); err != nil {
+		return
+	}
+	result = new(Key)
 	ScReduce(result, &reduceFrom)
+	reduceFrom = [KeyLength * 2]byte{}
 	return
 }
 
-func NewKeyPair() (privKey *Key, pubKey *Key) {
-	privKey = RandomScalar()
+// RandomScalar returns a random scalar from crypto/rand
+func RandomScalar() (result *Key) {
+	result, err := RandomScalarFrom(rand.Reader)
+	if err != nil {
+		// crypto/rand does not fail on supported platforms
+		panic(err)
+	}
+	return
+}
+
+// GenerateKeyPair creates a private/public key pair using rand as the
+// randomness source
+func GenerateKeyPair(rand io.Reader) (privKey *SecretKey, pubKey *Key, err error) {
+	if privKey, err = GenerateSecretKey(rand); err != nil {
+		return
+	}
 	pubKey = privKey.PubKey()
 	return
 }
 
+func NewKeyPair() (privKey *SecretKey, pubKey *Key) {
+	privKey, pubKey, err := GenerateKeyPair(rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	return
+}
+
+// randomIndex returns a uniformly distributed integer in [0, n) using
+// rejection sampling over 64 bit values read from rand
+func randomIndex(rand io.Reader, n int) (result int, err error) {
+	if n <= 0 {
+		err = fmt.Errorf("Cannot choose an index from %d elements", n)
+		return
+	}
+	max := uint64(n)
+	limit := math.MaxUint64 - math.MaxUint64%max
+	var b [8]byte
+	for {
+		if _, err = io.ReadFull(rand, b[:]); err != nil {
+			return
+		}
+		v := binary.LittleEndian.Uint64(b[:])
+		if v < limit {
+			result = int(v % max)
+			return
+		}
+	}
+}
+
 func ParseKey(buf io.Reader) (result Key, err error) {
 	key := make([]byte, KeyLength)
 	if _, err = buf.Read(key); err != nil {
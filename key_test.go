@@ -0,0 +1,85 @@
+package moneroutil
+
+import (
+	"testing"
+)
+
+func TestHashToPoint(t *testing.T) {
+	// hash_to_ec cases from github.com/monero-project/monero/tests/crypto/tests.txt
+	tests := []struct {
+		name     string
+		dataHex  string
+		pointHex string
+	}{
+		{
+			name:     "hash_to_ec 1",
+			dataHex:  "da66e9ba613919dec28ef367a125bb310d6d83fb9052e71034164b6dc4f392d0",
+			pointHex: "52b3f38753b4e13b74624862e253072cf12f745d43fcfafbe8c217701a6e5875",
+		},
+		{
+			name:     "hash_to_ec 2",
+			dataHex:  "a7fbdeeccb597c2d5fdaf2ea2e10cbfcd26b5740903e7f6d46bcbf9a90384fc6",
+			pointHex: "f055ba2d0d9828ce2e203d9896bfda494d7830e7e3a27fa27d5eaa825a79a19c",
+		},
+		{
+			name:     "hash_to_ec 3",
+			dataHex:  "bf180e20d160fa23ccfa6993febe22b920160efc5a9614245f1a3a360076e87a",
+			pointHex: "9d6454ff69779ce978ea5fb3be88576dc8feaedf151e93b70065f92505f2e800",
+		},
+	}
+	for _, test := range tests {
+		data := HexToKey(test.dataHex)
+		want := HexToKey(test.pointHex)
+		var got Key
+		HashToPoint(data[:]).ToBytes(&got)
+		if want != got {
+			t.Errorf("%s: want %x, got %x", test.name, want, got)
+		}
+	}
+}
+
+func TestFieldElementToPoint(t *testing.T) {
+	// hash_to_point cases from github.com/monero-project/monero/tests/crypto/tests.txt
+	tests := []struct {
+		dataHex  string
+		pointHex string
+	}{
+		{"83efb774657700e37291f4b8dd10c839d1c739fd135c07a2fd7382334dafdd6a", "2789ecbaf36e4fcb41c6157228001538b40ca379464b718d830c58caae7ea4ca"},
+		{"5c380f98794ab7a9be7c2d3259b92772125ce93527be6a76210631fdd8001498", "31a1feb4986d42e2137ae061ea031838d24fa523234954cf8860bcd42421ae94"},
+		{"4775d39f91a466262f0ccf21f5a7ee446f79a05448861e212be063a1063298f0", "897b3589f29ea40e576a91506d9aeca4c05a494922a80de57276f4b40c0a98bc"},
+	}
+	for _, test := range tests {
+		data := HexToKey(test.dataHex)
+		want := HexToKey(test.pointHex)
+		var got Key
+		FieldElementToPoint(&data).ToBytes(&got)
+		if want != got {
+			t.Errorf("%s: want %x, got %x", test.dataHex, want, got)
+		}
+	}
+}
+
+func TestRandomIndex(t *testing.T) {
+	reader := newTestReader("index")
+	for n := 1; n <= 16; n++ {
+		seen := make([]bool, n)
+		for i := 0; i < 50*n; i++ {
+			got, err := randomIndex(reader, n)
+			if err != nil {
+				t.Fatalf("%d: %s", n, err)
+			}
+			if got < 0 || got >= n {
+				t.Fatalf("%d: index %d out of range", n, got)
+			}
+			seen[got] = true
+		}
+		for i, ok := range seen {
+			if !ok {
+				t.Errorf("%d: index %d never chosen", n, i)
+			}
+		}
+	}
+	if _, err := randomIndex(reader, 0); err == nil {
+		t.Errorf("want error for empty range")
+	}
+}
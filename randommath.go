@@ -0,0 +1,231 @@
+package moneroutil
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// The random math of CryptoNight variant R: a program of 60 to 70
+// instructions on nine 32 bit registers, generated from the block height
+
+const (
+	v4Mul = iota
+	v4Add
+	v4Sub
+	v4Ror
+	v4Rol
+	v4Xor
+	v4InstructionCount
+)
+
+const (
+	v4TotalLatency       = 15 * 3
+	v4NumInstructionsMin = 60
+	v4NumInstructionsMax = 70
+	v4AluCountMul        = 1
+	v4AluCount           = 3
+)
+
+var (
+	v4OpLatency     = [v4InstructionCount]int{3, 2, 1, 2, 2, 1}
+	v4AsicOpLatency = [v4InstructionCount]int{3, 1, 1, 1, 1, 1}
+	v4OpAlus        = [v4InstructionCount]int{v4AluCountMul, v4AluCount, v4AluCount, v4AluCount, v4AluCount, v4AluCount}
+)
+
+type v4Instruction struct {
+	opcode   uint8
+	dstIndex uint8
+	srcIndex uint8
+	c        uint32
+}
+
+func v4IsRotation(opcode uint8) bool {
+	return opcode == v4Ror || opcode == v4Rol
+}
+
+// v4RandomMath runs code on the registers r
+func v4RandomMath(code []v4Instruction, r *[9]uint32) {
+	for _, inst := range code {
+		src := r[inst.srcIndex]
+		dst := &r[inst.dstIndex]
+		switch inst.opcode {
+		case v4Mul:
+			*dst *= src
+		case v4Add:
+			*dst += src + inst.c
+		case v4Sub:
+			*dst -= src
+		case v4Ror:
+			*dst = bits.RotateLeft32(*dst, -int(src%32))
+		case v4Rol:
+			*dst = bits.RotateLeft32(*dst, int(src%32))
+		case v4Xor:
+			*dst ^= src
+		}
+	}
+}
+
+// v4RandomMathInit generates the program for a height. It models a simple
+// CPU so that the program takes about the same time to run everywhere, and
+// pads it until an ASIC with unlimited ALUs would need as long.
+func v4RandomMathInit(height uint64) (result []v4Instruction) {
+	var data [32]byte
+	binary.LittleEndian.PutUint64(data[:], height)
+	data[20] = 0xda
+	dataIndex := len(data)
+	checkData := func(bytesNeeded int) {
+		if dataIndex+bytesNeeded > len(data) {
+			data = blake256(data[:])
+			dataIndex = 0
+		}
+	}
+
+	r8Used := false
+	for !r8Used || len(result) < v4NumInstructionsMin || len(result) > v4NumInstructionsMax {
+		var latency, asicLatency [9]int
+		// the previous instruction and source value of each register, with
+		// the constant registers R4-R8 treated as having the same value
+		instData := [9]uint32{0, 1, 2, 3, 0xffffff, 0xffffff, 0xffffff, 0xffffff, 0xffffff}
+		var aluBusy [v4TotalLatency + 1][v4AluCount]bool
+		var rotated [4]bool
+		rotateCount := 0
+		numRetries := 0
+		totalIterations := 0
+		result = result[:0]
+		r8Used = false
+
+		for (latency[0] < v4TotalLatency || latency[1] < v4TotalLatency ||
+			latency[2] < v4TotalLatency || latency[3] < v4TotalLatency) && numRetries < 64 {
+			totalIterations++
+			if totalIterations > 256 {
+				break
+			}
+			checkData(1)
+			c := data[dataIndex]
+			dataIndex++
+
+			opcode := c & 7
+			switch {
+			case opcode == 5:
+				checkData(1)
+				if int8(data[dataIndex]) >= 0 {
+					opcode = v4Ror
+				} else {
+					opcode = v4Rol
+				}
+				dataIndex++
+			case opcode >= 6:
+				opcode = v4Xor
+			case opcode <= 2:
+				opcode = v4Mul
+			default:
+				opcode -= 2
+			}
+			dstIndex := (c >> 3) & 3
+			srcIndex := (c >> 5) & 7
+			a := int(dstIndex)
+			// ADD, SUB and XOR of a register with itself use R8 instead
+			if (opcode == v4Add || opcode == v4Sub || opcode == v4Xor) && dstIndex == srcIndex {
+				srcIndex = 8
+			}
+			b := int(srcIndex)
+
+			// two rotations of a register are one rotation
+			if v4IsRotation(opcode) && rotated[a] {
+				continue
+			}
+			// and repeating anything but MUL with the same source value can
+			// be optimized away
+			if opcode != v4Mul && instData[a]&0xffff00 == uint32(opcode)<<8+(instData[b]&255)<<16 {
+				continue
+			}
+
+			// find when an ALU is free for this instruction
+			nextLatency := latency[a]
+			if latency[b] > nextLatency {
+				nextLatency = latency[b]
+			}
+			aluIndex := -1
+			for ; nextLatency < v4TotalLatency; nextLatency++ {
+				for i := v4OpAlus[opcode] - 1; i >= 0; i-- {
+					if aluBusy[nextLatency][i] {
+						continue
+					}
+					// ADD takes two cycles of the ALU
+					if opcode == v4Add && aluBusy[nextLatency+1][i] {
+						continue
+					}
+					// a rotation starts when the previous one is done
+					if v4IsRotation(opcode) && nextLatency < rotateCount*v4OpLatency[opcode] {
+						continue
+					}
+					aluIndex = i
+					break
+				}
+				if aluIndex >= 0 {
+					break
+				}
+			}
+
+			// no register is left unchanged for more than 7 cycles
+			if nextLatency > latency[a]+7 {
+				continue
+			}
+			nextLatency += v4OpLatency[opcode]
+			if nextLatency > v4TotalLatency {
+				numRetries++
+				continue
+			}
+
+			if v4IsRotation(opcode) {
+				rotateCount++
+			}
+			aluBusy[nextLatency-v4OpLatency[opcode]][aluIndex] = true
+			latency[a] = nextLatency
+			if asicLatency[b] > asicLatency[a] {
+				asicLatency[a] = asicLatency[b]
+			}
+			asicLatency[a] += v4AsicOpLatency[opcode]
+			rotated[a] = v4IsRotation(opcode)
+			instData[a] = uint32(len(result)) + uint32(opcode)<<8 + (instData[b]&255)<<16
+
+			inst := v4Instruction{opcode: opcode, dstIndex: dstIndex, srcIndex: srcIndex}
+			if srcIndex == 8 {
+				r8Used = true
+			}
+			if opcode == v4Add {
+				aluBusy[nextLatency-v4OpLatency[opcode]+1][aluIndex] = true
+				checkData(4)
+				inst.c = binary.LittleEndian.Uint32(data[dataIndex:])
+				dataIndex += 4
+			}
+			result = append(result, inst)
+			if len(result) >= v4NumInstructionsMin {
+				break
+			}
+		}
+
+		// pad with ROR, MUL, MUL on the least delayed register until an
+		// ASIC needs the full latency for at least one register
+		pattern := [3]uint8{v4Ror, v4Mul, v4Mul}
+		prevSize := len(result)
+		for len(result) < v4NumInstructionsMax && asicLatency[0] < v4TotalLatency &&
+			asicLatency[1] < v4TotalLatency && asicLatency[2] < v4TotalLatency &&
+			asicLatency[3] < v4TotalLatency {
+			minIndex, maxIndex := 0, 0
+			for i := 1; i < 4; i++ {
+				if asicLatency[i] < asicLatency[minIndex] {
+					minIndex = i
+				}
+				if asicLatency[i] > asicLatency[maxIndex] {
+					maxIndex = i
+				}
+			}
+			opcode := pattern[(len(result)-prevSize)%3]
+			latency[minIndex] = latency[maxIndex] + v4OpLatency[opcode]
+			asicLatency[minIndex] = asicLatency[maxIndex] + v4AsicOpLatency[opcode]
+			result = append(result, v4Instruction{opcode: opcode, dstIndex: uint8(minIndex), srcIndex: uint8(maxIndex)})
+		}
+	}
+	return
+}
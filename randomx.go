@@ -0,0 +1,718 @@
+package moneroutil
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// RandomX, the proof of work since block version 12, in light mode: the
+// dataset items are computed from the 256 MiB cache as they are needed
+// instead of being stored in a 2 GiB dataset.
+
+const (
+	randomxArgonMemory       = 262144
+	randomxArgonIterations   = 3
+	randomxArgonSalt         = "RandomX\x03"
+	randomxCacheAccesses     = 8
+	randomxDatasetBaseSize   = 2147483648
+	randomxDatasetExtraSize  = 33554368
+	randomxProgramSize       = 256
+	randomxProgramIterations = 2048
+	randomxProgramCount      = 8
+	randomxScratchpadL3      = 2097152
+	randomxScratchpadL2      = 262144
+	randomxScratchpadL1      = 16384
+	randomxJumpBits          = 8
+	randomxJumpOffset        = 8
+
+	randomxCacheLineSize       = 64
+	randomxCacheLineAlignMask  = (randomxDatasetBaseSize - 1) &^ (randomxCacheLineSize - 1)
+	randomxCacheLineCount      = randomxArgonMemory * argon2BlockLength / randomxCacheLineSize
+	randomxDatasetExtraItems   = randomxDatasetExtraSize / randomxCacheLineSize
+	randomxScratchpadL1Mask    = randomxScratchpadL1 - 8
+	randomxScratchpadL2Mask    = randomxScratchpadL2 - 8
+	randomxScratchpadL3Mask    = randomxScratchpadL3 - 8
+	randomxScratchpadL3Mask64  = randomxScratchpadL3 - 64
+	randomxStoreL3Condition    = 14
+	randomxProgramLength       = 128 + 8*randomxProgramSize
+	randomxRegisterFileLength  = 8*8 + 3*4*16
+	randomxFscalMask           = 0x80F0000000000000
+	randomxDynamicMantissaMask = 1<<56 - 1
+)
+
+// RandomX seed hashes change every SeedHashEpochBlocks blocks, and the new
+// one is used SeedHashEpochLag blocks after the block it comes from
+const (
+	SeedHashEpochBlocks = 2048
+	SeedHashEpochLag    = 64
+)
+
+// RandomXSeedHeight is the height of the block whose hash is the RandomX
+// key for blocks at height
+func RandomXSeedHeight(height uint64) uint64 {
+	if height <= SeedHashEpochBlocks+SeedHashEpochLag {
+		return 0
+	}
+	return (height - SeedHashEpochLag - 1) &^ (SeedHashEpochBlocks - 1)
+}
+
+// the constants that initialize the registers of a dataset item
+var (
+	superscalarMul0 = uint64(6364136223846793005)
+	superscalarAdds = [8]uint64{0, 9298411001130361340, 12065312585734608966, 9306329213124626780,
+		5281919268842080866, 10536153434571861004, 3398623926847679864, 9549104520008361294}
+)
+
+// RandomXCache holds the Argon2d memory and SuperscalarHash programs for a
+// key, which for Monero is the hash of the block at the seed height.
+// Building one takes a few seconds, so reuse it for all blocks of an
+// epoch.
+type RandomXCache struct {
+	key      []byte
+	memory   []uint64
+	programs [randomxCacheAccesses]superscalarProgram
+}
+
+func NewRandomXCache(key []byte) (result *RandomXCache) {
+	result = &RandomXCache{key: append([]byte(nil), key...)}
+	result.memory = argon2d(key, []byte(randomxArgonSalt), randomxArgonIterations, randomxArgonMemory)
+	gen := newBlake2Generator(key, 0)
+	for i := range result.programs {
+		result.programs[i] = generateSuperscalar(gen)
+	}
+	return
+}
+
+func (c *RandomXCache) Key() []byte {
+	return append([]byte(nil), c.key...)
+}
+
+// datasetItem computes the 64 byte dataset item number itemNumber
+func (c *RandomXCache) datasetItem(itemNumber uint64) (result [8]uint64) {
+	result[0] = (itemNumber + 1) * superscalarMul0
+	for i := 1; i < 8; i++ {
+		result[i] = result[0] ^ superscalarAdds[i]
+	}
+	registerValue := itemNumber
+	for i := range c.programs {
+		line := (registerValue % randomxCacheLineCount) * 8
+		executeSuperscalar(&result, &c.programs[i])
+		for q := range result {
+			result[q] ^= c.memory[line+uint64(q)]
+		}
+		registerValue = result[c.programs[i].addressRegister]
+	}
+	return
+}
+
+// aesKey lays out four 32 bit words, most significant first, as a block
+func aesKey(w3, w2, w1, w0 uint32) (result [16]byte) {
+	binary.LittleEndian.PutUint32(result[0:], w0)
+	binary.LittleEndian.PutUint32(result[4:], w1)
+	binary.LittleEndian.PutUint32(result[8:], w2)
+	binary.LittleEndian.PutUint32(result[12:], w3)
+	return
+}
+
+var (
+	aesGen1RKeys = [4][16]byte{
+		aesKey(0xb4f44917, 0xdbb5552b, 0x62716609, 0x6daca553),
+		aesKey(0x0da1dc4e, 0x1725d378, 0x846a710d, 0x6d7caf07),
+		aesKey(0x3e20e345, 0xf4c0794f, 0x9f947ec6, 0x3f1262f1),
+		aesKey(0x49169154, 0x16314c88, 0xb1ba317c, 0x6aef8135),
+	}
+	aesGen4RKeys = [8][16]byte{
+		aesKey(0x99e5d23f, 0x2f546d2b, 0xd1833ddb, 0x6421aadd),
+		aesKey(0xa5dfcde5, 0x06f79d53, 0xb6913f55, 0xb20e3450),
+		aesKey(0x171c02bf, 0x0aa4679f, 0x515e7baf, 0x5c3ed904),
+		aesKey(0xd8ded291, 0xcd673785, 0xe78f5d08, 0x85623763),
+		aesKey(0x229effb4, 0x3d518b6d, 0xe3d6a7a6, 0xb5826f73),
+		aesKey(0xb272b7d2, 0xe9024d4e, 0x9c10b3d9, 0xc7566bf3),
+		aesKey(0xf63befa7, 0x2ba9660a, 0xf765a38b, 0xf273c9e7),
+		aesKey(0xc0b0762d, 0x0c06d1fd, 0x915839de, 0x7a7cd609),
+	}
+	aesHash1RState = [4][16]byte{
+		aesKey(0xd7983aad, 0xcc82db47, 0x9fa856de, 0x92b52c0d),
+		aesKey(0xace78057, 0xf59e125a, 0x15c7b798, 0x338d996e),
+		aesKey(0xe8a07ce4, 0x5079506b, 0xae62c7d0, 0x6a770017),
+		aesKey(0x7e994948, 0x79a10005, 0x07ad828d, 0x630a240c),
+	}
+	aesHash1RXKeys = [2][16]byte{
+		aesKey(0x06890201, 0x90dc56bf, 0x8b24949f, 0xf6fa8389),
+		aesKey(0xed18f99b, 0xee1043c6, 0x51f4e03c, 0x61b263d1),
+	}
+)
+
+// fillAes1Rx4 fills output with one AES round per block on the four
+// columns of state, which is left at the last blocks
+func fillAes1Rx4(state []byte, output []byte) {
+	for i := 0; i < len(output); i += 64 {
+		aesDecRound(state[0:16], aesGen1RKeys[0][:])
+		aesRound(state[16:32], aesGen1RKeys[1][:])
+		aesDecRound(state[32:48], aesGen1RKeys[2][:])
+		aesRound(state[48:64], aesGen1RKeys[3][:])
+		copy(output[i:], state[:64])
+	}
+}
+
+// fillAes4Rx4 fills output like fillAes1Rx4 with four rounds per block,
+// leaving state unchanged
+func fillAes4Rx4(state []byte, output []byte) {
+	s := make([]byte, 64)
+	copy(s, state)
+	for i := 0; i < len(output); i += 64 {
+		for k := 0; k < 4; k++ {
+			aesDecRound(s[0:16], aesGen4RKeys[k][:])
+			aesRound(s[16:32], aesGen4RKeys[k][:])
+			aesDecRound(s[32:48], aesGen4RKeys[k+4][:])
+			aesRound(s[48:64], aesGen4RKeys[k+4][:])
+		}
+		copy(output[i:], s)
+	}
+}
+
+// hashAes1Rx4 hashes input, a multiple of 64 bytes, to 64 bytes
+func hashAes1Rx4(input []byte) (result []byte) {
+	result = make([]byte, 64)
+	for i := range aesHash1RState {
+		copy(result[16*i:], aesHash1RState[i][:])
+	}
+	for i := 0; i < len(input); i += 64 {
+		aesRound(result[0:16], input[i:i+16])
+		aesDecRound(result[16:32], input[i+16:i+32])
+		aesRound(result[32:48], input[i+32:i+48])
+		aesDecRound(result[48:64], input[i+48:i+64])
+	}
+	for _, key := range aesHash1RXKeys {
+		aesRound(result[0:16], key[:])
+		aesDecRound(result[16:32], key[:])
+		aesRound(result[32:48], key[:])
+		aesDecRound(result[48:64], key[:])
+	}
+	return
+}
+
+// floating point rounding modes, as set by CFROUND
+const (
+	roundToNearest = iota
+	roundDown
+	roundUp
+	roundTowardZero
+)
+
+// roundFloat turns the round to nearest result of an operation into the
+// result of rounding mode, given the sign of the exact value minus the
+// result. finite tells whether the operands were finite, in which case an
+// infinite result is an overflow.
+func roundFloat(result float64, errorSign float64, mode int, finite bool) float64 {
+	switch {
+	case math.IsInf(result, 1) && finite && (mode == roundDown || mode == roundTowardZero):
+		result = math.MaxFloat64
+	case math.IsInf(result, -1) && finite && (mode == roundUp || mode == roundTowardZero):
+		result = -math.MaxFloat64
+	case errorSign == 0 || math.IsNaN(errorSign) || math.IsInf(result, 0):
+	case mode == roundDown && errorSign < 0,
+		mode == roundTowardZero && errorSign < 0 && result > 0:
+		result = math.Nextafter(result, math.Inf(-1))
+	case mode == roundUp && errorSign > 0,
+		mode == roundTowardZero && errorSign > 0 && result < 0:
+		result = math.Nextafter(result, math.Inf(1))
+	}
+	return result
+}
+
+func isFinite(x float64) bool {
+	return !math.IsInf(x, 0) && !math.IsNaN(x)
+}
+
+func floatAdd(x, y float64, mode int) float64 {
+	s := x + y
+	if mode == roundToNearest {
+		return s
+	}
+	if s == 0 && mode == roundDown && (math.Signbit(x) || math.Signbit(y) || x != 0) {
+		// an exact zero sum is negative when rounding down
+		return math.Copysign(0, -1)
+	}
+	// the error of the sum is exact (TwoSum)
+	yy := s - x
+	e := (x - (s - yy)) + (y - yy)
+	return roundFloat(s, e, mode, isFinite(x) && isFinite(y))
+}
+
+func floatMul(x, y float64, mode int) float64 {
+	p := x * y
+	if mode == roundToNearest {
+		return p
+	}
+	return roundFloat(p, math.FMA(x, y, -p), mode, isFinite(x) && isFinite(y))
+}
+
+func floatDiv(x, y float64, mode int) float64 {
+	q := x / y
+	if mode == roundToNearest {
+		return q
+	}
+	// the remainder x - q*y is exact, and x/y - q has the sign of
+	// remainder/y
+	r := math.FMA(-q, y, x)
+	if math.Signbit(y) {
+		r = -r
+	}
+	return roundFloat(q, r, mode, isFinite(x) && isFinite(y))
+}
+
+func floatSqrt(x float64, mode int) float64 {
+	s := math.Sqrt(x)
+	if mode == roundToNearest {
+		return s
+	}
+	return roundFloat(s, math.FMA(-s, s, x), mode, true)
+}
+
+// the instructions of the RandomX virtual machine, with the number of
+// opcodes out of 256 that select each
+const (
+	rxIAddRs = iota
+	rxIAddM
+	rxISubR
+	rxISubM
+	rxIMulR
+	rxIMulM
+	rxIMulhR
+	rxIMulhM
+	rxISmulhR
+	rxISmulhM
+	rxIMulRcp
+	rxINegR
+	rxIXorR
+	rxIXorM
+	rxIRorR
+	rxIRolR
+	rxISwapR
+	rxFSwapR
+	rxFAddR
+	rxFAddM
+	rxFSubR
+	rxFSubM
+	rxFScalR
+	rxFMulR
+	rxFDivM
+	rxFSqrtR
+	rxCBranch
+	rxCFround
+	rxIStore
+	rxNop
+)
+
+var rxFrequencies = [...]int{
+	rxIAddRs: 16, rxIAddM: 7, rxISubR: 16, rxISubM: 7, rxIMulR: 16, rxIMulM: 4,
+	rxIMulhR: 4, rxIMulhM: 1, rxISmulhR: 4, rxISmulhM: 1, rxIMulRcp: 8, rxINegR: 2,
+	rxIXorR: 15, rxIXorM: 5, rxIRorR: 8, rxIRolR: 2, rxISwapR: 4, rxFSwapR: 4,
+	rxFAddR: 16, rxFAddM: 5, rxFSubR: 16, rxFSubM: 5, rxFScalR: 6, rxFMulR: 32,
+	rxFDivM: 4, rxFSqrtR: 6, rxCBranch: 25, rxCFround: 1, rxIStore: 16, rxNop: 0,
+}
+
+// rxOpcodes maps an opcode byte to an instruction
+var rxOpcodes [256]int
+
+func init() {
+	opcode := 0
+	for instruction, frequency := range rxFrequencies {
+		for i := 0; i < frequency; i++ {
+			rxOpcodes[opcode] = instruction
+			opcode++
+		}
+	}
+}
+
+// rxInstruction is a decoded instruction. A src of -1 uses imm instead of
+// a register.
+type rxInstruction struct {
+	opcode  int
+	dst     int
+	src     int
+	imm     uint64
+	memMask uint64
+	shift   uint
+	target  int
+}
+
+type randomxVM struct {
+	cache      *RandomXCache
+	scratchpad []byte
+	r          [8]uint64
+	f, e, a    [4][2]float64
+	ma, mx     uint32
+	readReg    [4]int
+	datasetOff uint64
+	eMask      [2]uint64
+	mode       int
+	program    [randomxProgramSize]rxInstruction
+}
+
+// smallPositiveFloatBits makes a float between 1 and 2^32 from entropy
+func smallPositiveFloatBits(entropy uint64) uint64 {
+	exponent := entropy>>59 + 1023
+	return exponent<<52 | entropy&(1<<52-1)
+}
+
+// floatMask keeps the E registers positive with an exponent fixed by the
+// program
+func floatMask(entropy uint64) uint64 {
+	exponent := uint64(0x300) | (entropy>>60)<<4
+	return entropy&(1<<22-1) | exponent<<52
+}
+
+// decode reads the program configuration and instructions, resolving
+// operands and branch targets
+func (vm *randomxVM) decode(program []byte) {
+	var entropy [16]uint64
+	for i := range entropy {
+		entropy[i] = binary.LittleEndian.Uint64(program[8*i:])
+	}
+	for i := range vm.a {
+		vm.a[i][0] = math.Float64frombits(smallPositiveFloatBits(entropy[2*i]))
+		vm.a[i][1] = math.Float64frombits(smallPositiveFloatBits(entropy[2*i+1]))
+	}
+	vm.ma = uint32(entropy[8] & randomxCacheLineAlignMask)
+	vm.mx = uint32(entropy[10])
+	addressRegisters := entropy[12]
+	for i := range vm.readReg {
+		vm.readReg[i] = 2*i + int(addressRegisters&1)
+		addressRegisters >>= 1
+	}
+	vm.datasetOff = entropy[13] % (randomxDatasetExtraItems + 1) * randomxCacheLineSize
+	vm.eMask[0] = floatMask(entropy[14])
+	vm.eMask[1] = floatMask(entropy[15])
+
+	// the last instruction that modified each register, for branches
+	registerUsage := [8]int{-1, -1, -1, -1, -1, -1, -1, -1}
+	for i := range vm.program {
+		b := program[128+8*i:]
+		mod := b[3]
+		imm32 := binary.LittleEndian.Uint32(b[4:])
+		inst := rxInstruction{
+			opcode: rxOpcodes[b[0]],
+			dst:    int(b[1] % 8),
+			src:    int(b[2] % 8),
+			imm:    signExtend32(imm32),
+		}
+		memMask := uint64(randomxScratchpadL2Mask)
+		if mod%4 != 0 {
+			memMask = randomxScratchpadL1Mask
+		}
+		switch inst.opcode {
+		case rxIAddM, rxISubM, rxIMulM, rxIMulhM, rxISmulhM, rxIXorM:
+			inst.memMask = memMask
+			if inst.src == inst.dst {
+				inst.src = -1
+				inst.memMask = randomxScratchpadL3Mask
+			}
+			registerUsage[inst.dst] = i
+		case rxIAddRs:
+			inst.shift = uint(mod>>2) % 4
+			if inst.dst != superscalarNeedsDisplacement {
+				inst.imm = 0
+			}
+			registerUsage[inst.dst] = i
+		case rxISubR, rxIMulR, rxIXorR, rxIRorR, rxIRolR:
+			if inst.src == inst.dst {
+				inst.src = -1
+			}
+			registerUsage[inst.dst] = i
+		case rxIMulhR, rxISmulhR, rxINegR:
+			registerUsage[inst.dst] = i
+		case rxIMulRcp:
+			if isZeroOrPowerOf2(imm32) {
+				inst.opcode = rxNop
+				break
+			}
+			inst.opcode = rxIMulR
+			inst.src = -1
+			inst.imm = randomxReciprocal(uint64(imm32))
+			registerUsage[inst.dst] = i
+		case rxISwapR:
+			if inst.src == inst.dst {
+				inst.opcode = rxNop
+				break
+			}
+			registerUsage[inst.dst] = i
+			registerUsage[inst.src] = i
+		case rxFAddM, rxFSubM, rxFDivM:
+			inst.memMask = memMask
+		case rxFSwapR:
+		case rxFAddR, rxFSubR, rxFScalR, rxFMulR, rxFSqrtR:
+			inst.dst %= 4
+			inst.src %= 4
+		case rxCBranch:
+			shift := uint(mod>>4) + randomxJumpOffset
+			inst.imm |= 1 << shift
+			inst.imm &^= 1 << (shift - 1)
+			inst.memMask = (1<<randomxJumpBits - 1) << shift
+			inst.target = registerUsage[inst.dst]
+			for j := range registerUsage {
+				registerUsage[j] = i
+			}
+		case rxCFround:
+			inst.imm = uint64(imm32 & 63)
+		case rxIStore:
+			inst.memMask = memMask
+			if mod>>4 >= randomxStoreL3Condition {
+				inst.memMask = randomxScratchpadL3Mask
+			}
+		}
+		vm.program[i] = inst
+	}
+}
+
+func (vm *randomxVM) load64(address uint64) uint64 {
+	return binary.LittleEndian.Uint64(vm.scratchpad[address:])
+}
+
+// loadFloats converts the two 32 bit integers at address
+func (vm *randomxVM) loadFloats(address uint64) (result [2]float64) {
+	result[0] = float64(int32(binary.LittleEndian.Uint32(vm.scratchpad[address:])))
+	result[1] = float64(int32(binary.LittleEndian.Uint32(vm.scratchpad[address+4:])))
+	return
+}
+
+// maskFloats makes loaded values positive with the E register exponent
+func (vm *randomxVM) maskFloats(x [2]float64) (result [2]float64) {
+	for i := range x {
+		bits := math.Float64bits(x[i])&randomxDynamicMantissaMask | vm.eMask[i]
+		result[i] = math.Float64frombits(bits)
+	}
+	return
+}
+
+// address computes the scratchpad address of a memory operand
+func (vm *randomxVM) address(inst *rxInstruction) uint64 {
+	base := uint64(0)
+	if inst.src >= 0 {
+		base = vm.r[inst.src]
+	}
+	return (base + inst.imm) & inst.memMask
+}
+
+// source is the register operand, or the immediate
+func (vm *randomxVM) source(inst *rxInstruction) uint64 {
+	if inst.src < 0 {
+		return inst.imm
+	}
+	return vm.r[inst.src]
+}
+
+func (vm *randomxVM) execute() {
+	for pc := 0; pc < randomxProgramSize; pc++ {
+		inst := &vm.program[pc]
+		dst := &vm.r[inst.dst]
+		switch inst.opcode {
+		case rxIAddRs:
+			*dst += vm.r[inst.src]<<inst.shift + inst.imm
+		case rxIAddM:
+			*dst += vm.load64(vm.address(inst))
+		case rxISubR:
+			*dst -= vm.source(inst)
+		case rxISubM:
+			*dst -= vm.load64(vm.address(inst))
+		case rxIMulR:
+			*dst *= vm.source(inst)
+		case rxIMulM:
+			*dst *= vm.load64(vm.address(inst))
+		case rxIMulhR:
+			*dst = mulh(*dst, vm.r[inst.src])
+		case rxIMulhM:
+			*dst = mulh(*dst, vm.load64(vm.address(inst)))
+		case rxISmulhR:
+			*dst = smulh(*dst, vm.r[inst.src])
+		case rxISmulhM:
+			*dst = smulh(*dst, vm.load64(vm.address(inst)))
+		case rxINegR:
+			*dst = -*dst
+		case rxIXorR:
+			*dst ^= vm.source(inst)
+		case rxIXorM:
+			*dst ^= vm.load64(vm.address(inst))
+		case rxIRorR:
+			*dst = bits.RotateLeft64(*dst, -int(vm.source(inst)&63))
+		case rxIRolR:
+			*dst = bits.RotateLeft64(*dst, int(vm.source(inst)&63))
+		case rxISwapR:
+			*dst, vm.r[inst.src] = vm.r[inst.src], *dst
+		case rxFSwapR:
+			if inst.dst < 4 {
+				vm.f[inst.dst][0], vm.f[inst.dst][1] = vm.f[inst.dst][1], vm.f[inst.dst][0]
+			} else {
+				vm.e[inst.dst-4][0], vm.e[inst.dst-4][1] = vm.e[inst.dst-4][1], vm.e[inst.dst-4][0]
+			}
+		case rxFAddR:
+			for i := 0; i < 2; i++ {
+				vm.f[inst.dst][i] = floatAdd(vm.f[inst.dst][i], vm.a[inst.src][i], vm.mode)
+			}
+		case rxFAddM:
+			x := vm.loadFloats(vm.address(inst))
+			for i := 0; i < 2; i++ {
+				vm.f[inst.dst%4][i] = floatAdd(vm.f[inst.dst%4][i], x[i], vm.mode)
+			}
+		case rxFSubR:
+			for i := 0; i < 2; i++ {
+				vm.f[inst.dst][i] = floatAdd(vm.f[inst.dst][i], -vm.a[inst.src][i], vm.mode)
+			}
+		case rxFSubM:
+			x := vm.loadFloats(vm.address(inst))
+			for i := 0; i < 2; i++ {
+				vm.f[inst.dst%4][i] = floatAdd(vm.f[inst.dst%4][i], -x[i], vm.mode)
+			}
+		case rxFScalR:
+			for i := 0; i < 2; i++ {
+				vm.f[inst.dst][i] = math.Float64frombits(math.Float64bits(vm.f[inst.dst][i]) ^ randomxFscalMask)
+			}
+		case rxFMulR:
+			for i := 0; i < 2; i++ {
+				vm.e[inst.dst][i] = floatMul(vm.e[inst.dst][i], vm.a[inst.src][i], vm.mode)
+			}
+		case rxFDivM:
+			x := vm.maskFloats(vm.loadFloats(vm.address(inst)))
+			for i := 0; i < 2; i++ {
+				vm.e[inst.dst%4][i] = floatDiv(vm.e[inst.dst%4][i], x[i], vm.mode)
+			}
+		case rxFSqrtR:
+			for i := 0; i < 2; i++ {
+				vm.e[inst.dst][i] = floatSqrt(vm.e[inst.dst][i], vm.mode)
+			}
+		case rxCBranch:
+			*dst += inst.imm
+			if *dst&inst.memMask == 0 {
+				pc = inst.target
+			}
+		case rxCFround:
+			vm.mode = int(bits.RotateLeft64(vm.r[inst.src], -int(inst.imm)) % 4)
+		case rxIStore:
+			binary.LittleEndian.PutUint64(vm.scratchpad[(*dst+inst.imm)&inst.memMask:], vm.r[inst.src])
+		}
+	}
+}
+
+// run generates a program from seed and runs it for
+// randomxProgramIterations iterations
+func (vm *randomxVM) run(seed []byte) {
+	program := make([]byte, randomxProgramLength)
+	fillAes4Rx4(seed, program)
+	vm.decode(program)
+	vm.r = [8]uint64{}
+
+	spAddr0 := uint64(vm.mx)
+	spAddr1 := uint64(vm.ma)
+	for ic := 0; ic < randomxProgramIterations; ic++ {
+		spMix := vm.r[vm.readReg[0]] ^ vm.r[vm.readReg[1]]
+		spAddr0 = (spAddr0 ^ spMix) & randomxScratchpadL3Mask64
+		spAddr1 = (spAddr1 ^ spMix>>32) & randomxScratchpadL3Mask64
+		for i := range vm.r {
+			vm.r[i] ^= vm.load64(spAddr0 + 8*uint64(i))
+		}
+		for i := range vm.f {
+			vm.f[i] = vm.loadFloats(spAddr1 + 8*uint64(i))
+		}
+		for i := range vm.e {
+			vm.e[i] = vm.maskFloats(vm.loadFloats(spAddr1 + 8*uint64(4+i)))
+		}
+
+		vm.execute()
+
+		vm.mx ^= uint32(vm.r[vm.readReg[2]] ^ vm.r[vm.readReg[3]])
+		vm.mx &= randomxCacheLineAlignMask
+		item := vm.cache.datasetItem((vm.datasetOff + uint64(vm.ma)) / randomxCacheLineSize)
+		for i := range vm.r {
+			vm.r[i] ^= item[i]
+		}
+		vm.mx, vm.ma = vm.ma, vm.mx
+
+		for i := range vm.r {
+			binary.LittleEndian.PutUint64(vm.scratchpad[spAddr1+8*uint64(i):], vm.r[i])
+		}
+		for i := range vm.f {
+			for j := 0; j < 2; j++ {
+				x := math.Float64bits(vm.f[i][j]) ^ math.Float64bits(vm.e[i][j])
+				vm.f[i][j] = math.Float64frombits(x)
+				binary.LittleEndian.PutUint64(vm.scratchpad[spAddr0+16*uint64(i)+8*uint64(j):], x)
+			}
+		}
+		spAddr0 = 0
+		spAddr1 = 0
+	}
+}
+
+// registerFile serializes the registers r, f, e and a
+func (vm *randomxVM) registerFile() (result []byte) {
+	result = make([]byte, randomxRegisterFileLength)
+	for i, x := range vm.r {
+		binary.LittleEndian.PutUint64(result[8*i:], x)
+	}
+	for i, group := range [][4][2]float64{vm.f, vm.e, vm.a} {
+		for j := range group {
+			for k := 0; k < 2; k++ {
+				binary.LittleEndian.PutUint64(result[64+64*i+16*j+8*k:], math.Float64bits(group[j][k]))
+			}
+		}
+	}
+	return
+}
+
+// Hash is the RandomX hash of data
+func (c *RandomXCache) Hash(data []byte) (result Hash) {
+	vm := &randomxVM{cache: c, scratchpad: make([]byte, randomxScratchpadL3)}
+	seed := blake2b(data, 64)
+	fillAes1Rx4(seed, vm.scratchpad)
+	for chain := 0; chain < randomxProgramCount; chain++ {
+		vm.run(seed)
+		if chain < randomxProgramCount-1 {
+			seed = blake2b(vm.registerFile(), 64)
+		}
+	}
+	// the a registers are replaced by a hash of the scratchpad
+	registers := vm.registerFile()
+	copy(registers[randomxRegisterFileLength-64:], hashAes1Rx4(vm.scratchpad))
+	copy(result[:], blake2b(registers, HashLength))
+	return
+}
+
+// RandomXHash is the proof of work hash of a block from version 12 on.
+// cache must be built from the hash of the block at RandomXSeedHeight.
+func (b *Block) RandomXHash(cache *RandomXCache) (result Hash, err error) {
+	if b.majorVersion < 12 {
+		err = fmt.Errorf("Block version %d uses CryptoNight", b.majorVersion)
+		return
+	}
+	result = cache.Hash(b.HashingBlob())
+	return
+}
+
+// PowHash is the proof of work hash of a block at height, using
+// CryptoNight or RandomX depending on its version. cache is only needed for
+// RandomX blocks.
+func (b *Block) PowHash(height uint64, cache *RandomXCache) (result Hash, err error) {
+	if b.majorVersion < 12 {
+		result, err = b.CryptoNightHash(height)
+		return
+	}
+	if cache == nil {
+		err = fmt.Errorf("Block version %d needs a RandomX cache", b.majorVersion)
+		return
+	}
+	result, err = b.RandomXHash(cache)
+	return
+}
+
+// CheckPow reports whether the proof of work of a block at height meets
+// difficulty
+func (b *Block) CheckPow(height uint64, difficulty Difficulty, cache *RandomXCache) (result bool, err error) {
+	hash, err := b.PowHash(height, cache)
+	if err != nil {
+		return
+	}
+	result = CheckHash(hash, difficulty)
+	return
+}
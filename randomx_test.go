@@ -0,0 +1,136 @@
+package moneroutil
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestRandomXHash(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		dataHex string
+		hashHex string
+	}{
+		{
+			name:    "test key 000 1",
+			key:     "test key 000",
+			dataHex: hex.EncodeToString([]byte("This is a test")),
+			hashHex: "639183aae1bf4c9a35884cb46b09cad9175f04efd7684e7262a0ac1c2f0b4e3f",
+		},
+		{
+			name:    "test key 000 2",
+			key:     "test key 000",
+			dataHex: hex.EncodeToString([]byte("Lorem ipsum dolor sit amet")),
+			hashHex: "300a0adb47603dedb42228ccb2b211104f4da45af709cd7547cd049e9489c969",
+		},
+		{
+			name:    "test key 000 3",
+			key:     "test key 000",
+			dataHex: hex.EncodeToString([]byte("sed do eiusmod tempor incididunt ut labore et dolore magna aliqua")),
+			hashHex: "c36d4ed4191e617309867ed66a443be4075014e2b061bcdaf9ce7b721d2b77a8",
+		},
+		{
+			name:    "test key 001 1",
+			key:     "test key 001",
+			dataHex: hex.EncodeToString([]byte("sed do eiusmod tempor incididunt ut labore et dolore magna aliqua")),
+			hashHex: "e9ff4503201c0c2cca26d285c93ae883f9b1d30c9eb240b820756f2d5a7905fc",
+		},
+		{
+			name:    "test key 001 2",
+			key:     "test key 001",
+			dataHex: "0b0b98bea7e805e0010a2126d287a2a0cc833d312cb786385a7c2f9de69d25537f584a9bc9977b00000000666fd8753bf61a8631f12984e3fd44f4014eca629276817b56f32e9b68bd82f416",
+			hashHex: "c56414121acda1713c2f2a819d8ae38aed7c80c35c2a769298d34f03833cd5f1",
+		},
+	}
+	var cache *RandomXCache
+	for _, test := range tests {
+		if cache == nil || string(cache.Key()) != test.key {
+			cache = NewRandomXCache([]byte(test.key))
+		}
+		data, _ := hex.DecodeString(test.dataHex)
+		want := HexToHash(test.hashHex)
+		got := cache.Hash(data)
+		if got != want {
+			t.Errorf("%s: want %x, got %x", test.name, want, got)
+		}
+	}
+}
+
+func TestRandomXDatasetItem(t *testing.T) {
+	cache := NewRandomXCache([]byte("test key 000"))
+	tests := []struct {
+		itemNumber uint64
+		want       uint64
+	}{
+		{0, 0x680588a85ae222db},
+		{10000000, 0x7943a1f6186ffb72},
+		{20000000, 0x9035244d718095e1},
+		{30000000, 0x145a5091f7853099},
+	}
+	for _, test := range tests {
+		got := cache.datasetItem(test.itemNumber)[0]
+		if got != test.want {
+			t.Errorf("%d: want %x, got %x", test.itemNumber, test.want, got)
+		}
+	}
+}
+
+func TestRandomXReciprocal(t *testing.T) {
+	tests := []struct {
+		divisor uint64
+		want    uint64
+	}{
+		{3, 12297829382473034410},
+		{13, 11351842506898185609},
+		{33, 17887751829051686415},
+		{65537, 18446462603027742720},
+		{15000001, 10316166306300415204},
+		{3845182035, 10302264209224146340},
+		{0xffffffff, 9223372039002259456},
+	}
+	for _, test := range tests {
+		got := randomxReciprocal(test.divisor)
+		if got != test.want {
+			t.Errorf("%d: want %d, got %d", test.divisor, test.want, got)
+		}
+	}
+}
+
+func TestRandomXSeedHeight(t *testing.T) {
+	tests := []struct {
+		height uint64
+		want   uint64
+	}{
+		{0, 0},
+		{2112, 0},
+		{2113, 2048},
+		{4160, 2048},
+		{4161, 4096},
+		{1978433, 1978368},
+	}
+	for _, test := range tests {
+		got := RandomXSeedHeight(test.height)
+		if got != test.want {
+			t.Errorf("%d: want %d, got %d", test.height, test.want, got)
+		}
+	}
+}
+
+func TestBlake2b(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		size    int
+		hashHex string
+	}{
+		{"empty 256", "", 32, "0e5751c026e543b2e8ab2eb06099daa1d1e5df47778f7787faab45cdf12fe3a8"},
+		{"abc 512", "abc", 64, "ba80a53f981c4d0d6a2797b69f12f6e94c212f14685ac4b74b12bb6fdbffa2d17d87c5392aab792dc252d5de4533cc9518d38aa8dbf1925ab92386edd4009923"},
+	}
+	for _, test := range tests {
+		got := hex.EncodeToString(blake2b([]byte(test.data), test.size))
+		if got != test.hashHex {
+			t.Errorf("%s: want %s, got %s", test.name, test.hashHex, got)
+		}
+	}
+}
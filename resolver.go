@@ -0,0 +1,25 @@
+package moneroutil
+
+import (
+	"fmt"
+)
+
+// OutputResolver looks up the one-time public key of an output already on
+// the chain, given its amount and global index among outputs of that amount
+type OutputResolver interface {
+	OutputKey(amount, globalIndex uint64) (Key, error)
+}
+
+// MemoryOutputResolver maps an amount to the keys of all outputs with that
+// amount, in global index order
+type MemoryOutputResolver map[uint64][]Key
+
+func (m MemoryOutputResolver) OutputKey(amount, globalIndex uint64) (result Key, err error) {
+	keys := m[amount]
+	if globalIndex >= uint64(len(keys)) {
+		err = fmt.Errorf("No output with amount %d and index %d", amount, globalIndex)
+		return
+	}
+	result = keys[globalIndex]
+	return
+}
@@ -0,0 +1,81 @@
+package moneroutil
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+const (
+	// All coins that will ever be emitted before the tail emission, in
+	// atomic units
+	MoneySupply = math.MaxUint64
+
+	// The base reward is the remaining supply shifted right by this, less
+	// one per minute of block time over one
+	EmissionSpeedFactorPerMinute = 20
+
+	// The tail emission per minute of block time, 0.6 XMR per block from
+	// hard fork version 2
+	FinalSubsidyPerMinute = 300000000000
+
+	// Hard fork version from which the coinbase must claim the whole
+	// reward and fees
+	ExactCoinbaseVersion = 13
+)
+
+// BlockReward is the reward for mining a block of currentBlockWeight after
+// alreadyGeneratedCoins have been emitted, without fees. Blocks heavier
+// than the median weight of recent blocks are penalized, and blocks over
+// twice the median are invalid.
+func BlockReward(medianWeight, currentBlockWeight, alreadyGeneratedCoins uint64, hfVersion uint8) (reward uint64, err error) {
+	targetMinutes := DifficultyTarget(hfVersion) / 60
+	emissionSpeedFactor := EmissionSpeedFactorPerMinute - (targetMinutes - 1)
+	baseReward := (MoneySupply - alreadyGeneratedCoins) >> emissionSpeedFactor
+	if baseReward < FinalSubsidyPerMinute*targetMinutes {
+		baseReward = FinalSubsidyPerMinute * targetMinutes
+	}
+
+	minBlockWeight := MinBlockWeight(hfVersion)
+	if medianWeight < minBlockWeight {
+		medianWeight = minBlockWeight
+	}
+	if currentBlockWeight <= medianWeight {
+		reward = baseReward
+		return
+	}
+	if currentBlockWeight > 2*medianWeight {
+		err = fmt.Errorf("Block weight %d is more than twice the median %d", currentBlockWeight, medianWeight)
+		return
+	}
+	// baseReward * (2 - weight/median) * weight/median
+	hi, lo := bits.Mul64(baseReward, (2*medianWeight-currentBlockWeight)*currentBlockWeight)
+	hi, lo = div128(hi, lo, medianWeight)
+	_, reward = div128(hi, lo, medianWeight)
+	return
+}
+
+// CheckCoinbaseReward checks that the coinbase of a block of blockWeight
+// claims no more than the block reward plus fees of its transactions, and
+// exactly that before hard fork version 2 and from ExactCoinbaseVersion
+func (b *Block) CheckCoinbaseReward(medianWeight, blockWeight, alreadyGeneratedCoins, fees uint64) (err error) {
+	reward, err := BlockReward(medianWeight, blockWeight, alreadyGeneratedCoins, b.majorVersion)
+	if err != nil {
+		return
+	}
+	if reward+fees < reward {
+		err = fmt.Errorf("Block reward %d plus fees %d overflows", reward, fees)
+		return
+	}
+	claimed, err := b.MinerTx.OutputSumChecked()
+	if err != nil {
+		return
+	}
+	switch {
+	case claimed > reward+fees:
+		err = fmt.Errorf("Coinbase claims %d, more than the reward %d plus fees %d", claimed, reward, fees)
+	case claimed < reward+fees && (b.majorVersion < 2 || b.majorVersion >= ExactCoinbaseVersion):
+		err = fmt.Errorf("Coinbase claims %d, less than the reward %d plus fees %d", claimed, reward, fees)
+	}
+	return
+}
@@ -0,0 +1,71 @@
+package moneroutil
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestBlockReward(t *testing.T) {
+	tests := []struct {
+		name                  string
+		medianWeight          uint64
+		currentBlockWeight    uint64
+		alreadyGeneratedCoins uint64
+		hfVersion             uint8
+		want                  uint64
+	}{
+		{"genesis", 0, 0, 0, 1, 17592186044415},
+		{"block 1", 0, 0, 17592186044415, 1, 17592169267200},
+		{"two minute blocks", 0, 0, 0, 2, 35184372088831},
+		{"tail emission", 300000, 300000, MoneySupply - 1000, 16, 600000000000},
+		{"tail emission v1", 20000, 20000, MoneySupply, 1, 300000000000},
+		{"below minimum median", 1000, 300000, MoneySupply, 16, 600000000000},
+		{"penalty", 300000, 450000, MoneySupply, 16, 450000000000},
+		{"penalty 128 bit", 300000, 300001, 0, 16, 35184372088440},
+	}
+	for _, test := range tests {
+		got, err := BlockReward(test.medianWeight, test.currentBlockWeight, test.alreadyGeneratedCoins, test.hfVersion)
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("%s: want %d, got %d", test.name, test.want, got)
+		}
+	}
+	if _, err := BlockReward(300000, 600001, 0, 16); err == nil {
+		t.Errorf("too heavy: want error")
+	}
+}
+
+func TestCheckCoinbaseReward(t *testing.T) {
+	genesis, _ := hex.DecodeString("010000000000000000000000000000000000000000000000000000000000000000000010270000013c01ff0001ffffffffffff03029b2e4c0281c0b02e7c53291a94d1d0cbff8883f8024f5142ee494ffbbd08807121017767aafcde9be00dcfd098715ebcf7f410daebc582fda69d24a28e9d0bc890d100")
+	block, err := ParseBlock(bytes.NewReader(genesis))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = block.CheckCoinbaseReward(0, 80, 0, 0); err != nil {
+		t.Errorf("genesis: %s", err)
+	}
+	if err = block.CheckCoinbaseReward(0, 80, 0, 1); err == nil {
+		t.Errorf("genesis with fees: want error for claiming less before version 2")
+	}
+	if err = block.CheckCoinbaseReward(0, 80, 1<<30, 0); err == nil {
+		t.Errorf("genesis later: want error for claiming more")
+	}
+	block.majorVersion = 5
+	if err = block.CheckCoinbaseReward(0, 80, 0, 0); err != nil {
+		t.Errorf("version 5: want claiming less allowed, got %s", err)
+	}
+	block.majorVersion = ExactCoinbaseVersion
+	if err = block.CheckCoinbaseReward(0, 80, 0, 0); err == nil {
+		t.Errorf("version %d: want error for claiming less", ExactCoinbaseVersion)
+	}
+	// the amounts add up to 0 when they wrap
+	block.majorVersion = 5
+	block.MinerTx.vout = []*TxOut{{amount: 1 << 63}, {amount: 1 << 63}}
+	if err = block.CheckCoinbaseReward(0, 80, 0, 0); err == nil {
+		t.Errorf("overflowing outputs: want error")
+	}
+}
@@ -5,16 +5,22 @@ import (
 	"io"
 )
 
+// RingCT signature types. Bulletproof2 and later encrypt amounts in 8
+// bytes and keep the pseudo outputs in the prunable part.
 const (
 	RCTTypeNull = iota
 	RCTTypeFull
 	RCTTypeSimple
+	RCTTypeBulletproof
+	RCTTypeBulletproof2
+	RCTTypeCLSAG
+	RCTTypeBulletproofPlus
 )
 
 // Pedersen Commitment is generated from this struct
 // C = aG + bH where a = mask and b = amount
 // senderPk is the one-time public key for ECDH exchange
-type ecdhTuple struct {
+type EcdhTuple struct {
 	mask     Key
 	amount   Key
 	senderPk Key
@@ -56,15 +62,18 @@ type RctSigBase struct {
 	message    Key
 	mixRing    [][]CtKey
 	pseudoOuts []Key
-	ecdhInfo   []ecdhTuple
+	ecdhInfo   []EcdhTuple
 	outPk      []CtKey
 	txFee      uint64
 }
 
 // Ring Confidential Signature parts that we can just prune later
 type RctSigPrunable struct {
-	rangeSigs []RangeSig
-	mlsagSigs []MlsagSig
+	rangeSigs        []RangeSig
+	bulletproofs     []Bulletproof
+	bulletproofsPlus []BulletproofPlus
+	mlsagSigs        []MlsagSig
+	clsagSigs        []ClsagSig
 }
 
 // Ring Confidential Signature struct that can verify everything
@@ -73,6 +82,162 @@ type RctSig struct {
 	RctSigPrunable
 }
 
+// clone returns a deep copy of the signature, sharing no slices with it
+func (r *RctSig) clone() (result *RctSig) {
+	result = &RctSig{RctSigBase: r.RctSigBase}
+	result.mixRing = make([][]CtKey, len(r.mixRing))
+	for i, ring := range r.mixRing {
+		result.mixRing[i] = append([]CtKey(nil), ring...)
+	}
+	result.pseudoOuts = append([]Key(nil), r.pseudoOuts...)
+	result.ecdhInfo = append([]EcdhTuple(nil), r.ecdhInfo...)
+	result.outPk = append([]CtKey(nil), r.outPk...)
+	result.rangeSigs = append([]RangeSig(nil), r.rangeSigs...)
+	for _, proof := range r.bulletproofs {
+		result.bulletproofs = append(result.bulletproofs, *proof.clone())
+	}
+	for _, proof := range r.bulletproofsPlus {
+		result.bulletproofsPlus = append(result.bulletproofsPlus, *proof.clone())
+	}
+	for _, sig := range r.mlsagSigs {
+		result.mlsagSigs = append(result.mlsagSigs, *sig.clone())
+	}
+	for _, sig := range r.clsagSigs {
+		result.clsagSigs = append(result.clsagSigs, *sig.clone())
+	}
+	return
+}
+
+func (m *MlsagSig) clone() (result *MlsagSig) {
+	result = &MlsagSig{cc: m.cc, ii: append([]Key(nil), m.ii...)}
+	for _, ss := range m.ss {
+		result.ss = append(result.ss, append([]Key(nil), ss...))
+	}
+	return
+}
+
+func (e *EcdhTuple) Mask() Key {
+	return e.mask
+}
+
+func (e *EcdhTuple) Amount() Key {
+	return e.amount
+}
+
+func (b *BoroSig) S0() Key64 {
+	return b.s0
+}
+
+func (b *BoroSig) S1() Key64 {
+	return b.s1
+}
+
+func (b *BoroSig) Ee() Key {
+	return b.ee
+}
+
+// Ss returns a copy of the responses, one row per ring member
+func (m *MlsagSig) Ss() (result [][]Key) {
+	result = make([][]Key, len(m.ss))
+	for i := range m.ss {
+		result[i] = append([]Key(nil), m.ss[i]...)
+	}
+	return
+}
+
+func (m *MlsagSig) Cc() Key {
+	return m.cc
+}
+
+// Ii returns a copy of the key images
+func (m *MlsagSig) Ii() (result []Key) {
+	result = append([]Key(nil), m.ii...)
+	return
+}
+
+func (r *RangeSig) Asig() BoroSig {
+	return r.asig
+}
+
+func (r *RangeSig) Ci() Key64 {
+	return r.ci
+}
+
+func (c *CtKey) Destination() Key {
+	return c.destination
+}
+
+// Mask returns the Pedersen commitment, or the blinding factor when the
+// CtKey holds secrets
+func (c *CtKey) Mask() Key {
+	return c.mask
+}
+
+func (r *RctSigBase) Type() uint8 {
+	return r.sigType
+}
+
+func (r *RctSigBase) Message() Key {
+	return r.message
+}
+
+// MixRing returns a copy of the ring members, which are only known after
+// ExpandTransaction
+func (r *RctSigBase) MixRing() (result [][]CtKey) {
+	result = make([][]CtKey, len(r.mixRing))
+	for i := range r.mixRing {
+		result[i] = append([]CtKey(nil), r.mixRing[i]...)
+	}
+	return
+}
+
+func (r *RctSigBase) PseudoOuts() (result []Key) {
+	result = append([]Key(nil), r.pseudoOuts...)
+	return
+}
+
+func (r *RctSigBase) EcdhInfo() (result []EcdhTuple) {
+	result = append([]EcdhTuple(nil), r.ecdhInfo...)
+	return
+}
+
+func (r *RctSigBase) OutPk() (result []CtKey) {
+	result = append([]CtKey(nil), r.outPk...)
+	return
+}
+
+func (r *RctSigBase) TxFee() uint64 {
+	return r.txFee
+}
+
+func (r *RctSigPrunable) RangeSigs() (result []RangeSig) {
+	result = append([]RangeSig(nil), r.rangeSigs...)
+	return
+}
+
+func (r *RctSigPrunable) MlsagSigs() (result []MlsagSig) {
+	result = make([]MlsagSig, len(r.mlsagSigs))
+	for i := range r.mlsagSigs {
+		result[i] = MlsagSig{ss: r.mlsagSigs[i].Ss(), cc: r.mlsagSigs[i].cc, ii: r.mlsagSigs[i].Ii()}
+	}
+	return
+}
+
+func (r *RctSigPrunable) Bulletproofs() (result []Bulletproof) {
+	result = append([]Bulletproof(nil), r.bulletproofs...)
+	return
+}
+
+func (r *RctSigPrunable) BulletproofsPlus() (result []BulletproofPlus) {
+	result = append([]BulletproofPlus(nil), r.bulletproofsPlus...)
+	return
+}
+
+func (r *RctSigPrunable) ClsagSigs() (result []ClsagSig) {
+	result = append([]ClsagSig(nil), r.clsagSigs...)
+	return
+}
+
 func (k *Key) ToExtended() (result *ExtendedGroupElement) {
 	result = new(ExtendedGroupElement)
 	result.FromBytes(k)
@@ -85,16 +250,6 @@ func identity() (result *Key) {
 	return
 }
 
-// convert a uint64 to a scalar
-func d2h(val uint64) (result *Key) {
-	result = new(Key)
-	for i := 0; val > 0; i++ {
-		result[i] = byte(val & 0xFF)
-		val /= 256
-	}
-	return
-}
-
 // multiply a scalar by H (second curve point of Pedersen Commitment)
 func ScalarMultH(scalar *Key) (result *Key) {
 	h := new(ExtendedGroupElement)
@@ -141,6 +296,39 @@ func SubKeys(diff, k1, k2 *Key) {
 	return
 }
 
+// multiExp computes gScalar*G plus the sum of scalars[i]*points[i]
+func multiExp(result *ExtendedGroupElement, gScalar *Key, scalars []Key, points []ExtendedGroupElement) {
+	GeScalarMultBase(result, gScalar)
+	var p ProjectiveGroupElement
+	var e ExtendedGroupElement
+	for i := range scalars {
+		GeScalarMult(&p, &scalars[i], &points[i])
+		p.ToExtended(&e)
+		GeAdd(result, result, &e)
+	}
+}
+
+// scalarMult8 decodes k and multiplies it by 8, clearing any small order
+// component. It fails if k is not a valid point.
+func scalarMult8(result *ExtendedGroupElement, k *Key) bool {
+	var p ProjectiveGroupElement
+	var c CompletedGroupElement
+	if !result.FromBytes(k) {
+		return false
+	}
+	result.ToProjective(&p)
+	GeMul8(&c, &p)
+	c.ToExtended(result)
+	return true
+}
+
+func serializeKeys(keys []Key) (result []byte) {
+	for _, key := range keys {
+		result = append(result, key[:]...)
+	}
+	return
+}
+
 func (k *Key64) Serialize() (result []byte) {
 	for _, key := range k {
 		result = append(result, key[:]...)
@@ -182,6 +370,10 @@ func (r *RctSigBase) SerializeBase() (result []byte) {
 		}
 	}
 	for _, ecdh := range r.ecdhInfo {
+		if r.compactAmounts() {
+			result = append(result, ecdh.amount[:8]...)
+			continue
+		}
 		result = append(result, ecdh.mask[:]...)
 		result = append(result, ecdh.amount[:]...)
 	}
@@ -191,6 +383,17 @@ func (r *RctSigBase) SerializeBase() (result []byte) {
 	return
 }
 
+// compactAmounts reports whether amounts are encrypted in 8 bytes with no
+// mask
+func (r *RctSigBase) compactAmounts() bool {
+	return r.sigType >= RCTTypeBulletproof2
+}
+
+// usesClsag reports whether inputs are signed with CLSAG rather than MLSAG
+func (r *RctSigBase) usesClsag() bool {
+	return r.sigType == RCTTypeCLSAG || r.sigType == RCTTypeBulletproofPlus
+}
+
 func (r *RctSigBase) BaseHash() (result Hash) {
 	result = Keccak256(r.SerializeBase())
 	return
@@ -200,12 +403,40 @@ func (r *RctSig) SerializePrunable() (result []byte) {
 	if r.sigType == RCTTypeNull {
 		return
 	}
-	for _, rangeSig := range r.rangeSigs {
-		result = append(result, rangeSig.Serialize()...)
+	switch r.sigType {
+	case RCTTypeFull, RCTTypeSimple:
+		for _, rangeSig := range r.rangeSigs {
+			result = append(result, rangeSig.Serialize()...)
+		}
+	case RCTTypeBulletproof:
+		// the first bulletproof type stores the count as a 4 byte integer
+		count := uint32(len(r.bulletproofs))
+		result = []byte{byte(count), byte(count >> 8), byte(count >> 16), byte(count >> 24)}
+		for _, proof := range r.bulletproofs {
+			result = append(result, proof.Serialize()...)
+		}
+	case RCTTypeBulletproof2, RCTTypeCLSAG:
+		result = Uint64ToBytes(uint64(len(r.bulletproofs)))
+		for _, proof := range r.bulletproofs {
+			result = append(result, proof.Serialize()...)
+		}
+	case RCTTypeBulletproofPlus:
+		result = Uint64ToBytes(uint64(len(r.bulletproofsPlus)))
+		for _, proof := range r.bulletproofsPlus {
+			result = append(result, proof.Serialize()...)
+		}
 	}
 	for _, mlsagSig := range r.mlsagSigs {
 		result = append(result, mlsagSig.Serialize()...)
 	}
+	for _, clsagSig := range r.clsagSigs {
+		result = append(result, clsagSig.Serialize()...)
+	}
+	if r.sigType >= RCTTypeBulletproof {
+		for _, pseudoOut := range r.pseudoOuts {
+			result = append(result, pseudoOut[:]...)
+		}
+	}
 	return
 }
 
@@ -217,6 +448,75 @@ func (r *RctSig) PrunableHash() (result Hash) {
 	return
 }
 
+// proofCommitments returns the commitments V of a range proof covering all
+// the outputs: the output commitments divided by 8
+func (r *RctSig) proofCommitments() (result []Key) {
+	result = make([]Key, len(r.outPk))
+	for i := range r.outPk {
+		AddKeys2(&result[i], &Zero, &invEight, &r.outPk[i].mask)
+	}
+	return
+}
+
+// SignatureHash is the message signed by the ring signatures: the hash of
+// the prefix hash, the base hash and the hash of the range proofs
+func (r *RctSig) SignatureHash() (result Key) {
+	var proofs []byte
+	for _, rangeSig := range r.rangeSigs {
+		proofs = append(proofs, rangeSig.Serialize()...)
+	}
+	for _, proof := range r.bulletproofs {
+		proofs = append(proofs, proof.hashKeys()...)
+	}
+	for _, proof := range r.bulletproofsPlus {
+		proofs = append(proofs, proof.hashKeys()...)
+	}
+	baseHash := r.BaseHash()
+	proofsHash := Keccak256(proofs)
+	result = Key(Keccak256(r.message[:], baseHash[:], proofsHash[:]))
+	return
+}
+
+// Commit computes the Pedersen commitment mask*G + amount*H
+func Commit(amount uint64, mask *Key) (result Key) {
+	var amountKey Key
+	ScFromUint64(&amountKey, amount)
+	AddKeys2(&result, mask, &amountKey, &H)
+	return
+}
+
+// CommitmentMask derives the mask of an output commitment from its amount
+// key, which is DerivationToScalar of the output
+func CommitmentMask(amountKey *Key) (result *Key) {
+	result = HashToScalar([]byte("commitment_mask"), amountKey[:])
+	return
+}
+
+// amountPad is the keystream the 8 byte amounts are encrypted with
+func amountPad(amountKey *Key) (result Hash) {
+	result = Keccak256([]byte("amount"), amountKey[:])
+	return
+}
+
+// NewEcdhTuple encrypts an amount for the owner of amountKey
+func NewEcdhTuple(amount uint64, amountKey *Key) (result EcdhTuple) {
+	pad := amountPad(amountKey)
+	for i := 0; i < 8; i++ {
+		result.amount[i] = byte(amount>>uint(8*i)) ^ pad[i]
+	}
+	return
+}
+
+// DecodeAmount decrypts the 8 byte amount of a RCTTypeBulletproof2 or
+// later output with its amount key
+func (e *EcdhTuple) DecodeAmount(amountKey *Key) (amount uint64) {
+	pad := amountPad(amountKey)
+	for i := 0; i < 8; i++ {
+		amount |= uint64(e.amount[i]^pad[i]) << uint(8*i)
+	}
+	return
+}
+
 func verBorromean(b *BoroSig, p1, p2 *Key64) bool {
 	var data []byte
 	tmp, tmp2 := new(Key), new(Key)
@@ -249,7 +549,9 @@ func (r *RctSig) VerifyRctSimple() bool {
 	for _, ctKey := range r.outPk {
 		AddKeys(sumOutPks, sumOutPks, &ctKey.mask)
 	}
-	txFeeKey := ScalarMultH(d2h(r.txFee))
+	txFee := new(Key)
+	ScFromUint64(txFee, r.txFee)
+	txFeeKey := ScalarMultH(txFee)
 	AddKeys(sumOutPks, sumOutPks, txFeeKey)
 	sumPseudoOuts := identity()
 	for _, pseudoOut := range r.pseudoOuts {
@@ -266,6 +568,47 @@ func (r *RctSig) VerifyRctSimple() bool {
 	return true
 }
 
+// VerifyRctBulletproofPlus verifies a RCTTypeBulletproofPlus RingCT
+// Signature: the amounts balance, the range proof holds and every input has
+// a valid CLSAG. The transaction must be expanded first.
+func (r *RctSig) VerifyRctBulletproofPlus() bool {
+	if r.sigType != RCTTypeBulletproofPlus || len(r.bulletproofsPlus) != 1 {
+		return false
+	}
+	if len(r.clsagSigs) != len(r.mixRing) || len(r.pseudoOuts) != len(r.mixRing) {
+		return false
+	}
+	if len(r.ecdhInfo) != len(r.outPk) {
+		return false
+	}
+	sumOutPks := identity()
+	for _, ctKey := range r.outPk {
+		AddKeys(sumOutPks, sumOutPks, &ctKey.mask)
+	}
+	txFee := new(Key)
+	ScFromUint64(txFee, r.txFee)
+	AddKeys(sumOutPks, sumOutPks, ScalarMultH(txFee))
+	sumPseudoOuts := identity()
+	for _, pseudoOut := range r.pseudoOuts {
+		AddKeys(sumPseudoOuts, sumPseudoOuts, &pseudoOut)
+	}
+	if *sumPseudoOuts != *sumOutPks {
+		return false
+	}
+	proof := r.bulletproofsPlus[0]
+	proof.v = r.proofCommitments()
+	if !proof.Verify() {
+		return false
+	}
+	message := r.SignatureHash()
+	for i := range r.clsagSigs {
+		if !r.clsagSigs[i].Verify(&message, r.mixRing[i], &r.pseudoOuts[i]) {
+			return false
+		}
+	}
+	return true
+}
+
 func (r *RctSig) VerifyRctFull() bool {
 	for i, ctKey := range r.outPk {
 		if !verRange(&ctKey.mask, r.rangeSigs[i]) {
@@ -314,11 +657,30 @@ func ParseRangeSig(buf io.Reader) (result RangeSig, err error) {
 	return
 }
 
-func ParseRingCtSignature(buf io.Reader, nInputs, nOutputs, nMixin int) (result *RctSig, err error) {
+// parseKeyVector reads a varint count followed by that many keys
+func parseKeyVector(buf io.Reader) (result []Key, err error) {
+	count, err := readCount(buf, KeyLength)
+	if err != nil {
+		return
+	}
+	var keys []Key
+	for i := uint64(0); i < count; i++ {
+		var key Key
+		if _, err = io.ReadFull(buf, key[:]); err != nil {
+			return
+		}
+		keys = append(keys, key)
+	}
+	result = keys
+	return
+}
+
+// ParseRctSigBase reads the part of a RingCT signature that is kept when
+// the transaction is pruned
+func ParseRctSigBase(buf io.Reader, nInputs, nOutputs int) (result *RctSig, err error) {
 	r := new(RctSig)
 	sigType := make([]byte, 1)
-	_, err = buf.Read(sigType)
-	if err != nil {
+	if _, err = io.ReadFull(buf, sigType); err != nil {
 		return
 	}
 	r.sigType = uint8(sigType[0])
@@ -326,29 +688,29 @@ func ParseRingCtSignature(buf io.Reader, nInputs, nOutputs, nMixin int) (result
 		result = r
 		return
 	}
-	if r.sigType != RCTTypeFull || r.sigType != RCTTypeSimple {
+	if r.sigType > RCTTypeBulletproofPlus {
 		err = fmt.Errorf("Bad sigType %d", r.sigType)
+		return
 	}
-	r.txFee, err = ReadVarInt(buf)
-	if err != nil {
+	if r.txFee, err = ReadVarInt(buf); err != nil {
 		return
 	}
-	var nMg, nSS int
 	if r.sigType == RCTTypeSimple {
-		nMg = nInputs
-		nSS = 2
 		r.pseudoOuts = make([]Key, nInputs)
 		for i := 0; i < nInputs; i++ {
 			if r.pseudoOuts[i], err = ParseKey(buf); err != nil {
 				return
 			}
 		}
-	} else {
-		nMg = 1
-		nSS = nInputs + 1
 	}
-	r.ecdhInfo = make([]ecdhTuple, nOutputs)
+	r.ecdhInfo = make([]EcdhTuple, nOutputs)
 	for i := 0; i < nOutputs; i++ {
+		if r.compactAmounts() {
+			if _, err = io.ReadFull(buf, r.ecdhInfo[i].amount[:8]); err != nil {
+				return
+			}
+			continue
+		}
 		if r.ecdhInfo[i].mask, err = ParseKey(buf); err != nil {
 			return
 		}
@@ -362,27 +724,106 @@ func ParseRingCtSignature(buf io.Reader, nInputs, nOutputs, nMixin int) (result
 			return
 		}
 	}
-	r.rangeSigs = make([]RangeSig, nOutputs)
-	for i := 0; i < nOutputs; i++ {
-		if r.rangeSigs[i], err = ParseRangeSig(buf); err != nil {
+	result = r
+	return
+}
+
+// parsePrunable reads the range proofs, ring signatures and, for the
+// bulletproof types, the pseudo outputs
+func (r *RctSig) parsePrunable(buf io.Reader, nInputs, nOutputs, nMixin int) (err error) {
+	switch r.sigType {
+	case RCTTypeNull:
+		return
+	case RCTTypeFull, RCTTypeSimple:
+		r.rangeSigs = make([]RangeSig, nOutputs)
+		for i := 0; i < nOutputs; i++ {
+			if r.rangeSigs[i], err = ParseRangeSig(buf); err != nil {
+				return
+			}
+		}
+	case RCTTypeBulletproof, RCTTypeBulletproof2, RCTTypeCLSAG, RCTTypeBulletproofPlus:
+		var nProofs uint64
+		if r.sigType == RCTTypeBulletproof {
+			count := make([]byte, 4)
+			if _, err = io.ReadFull(buf, count); err != nil {
+				return
+			}
+			nProofs = uint64(count[0]) | uint64(count[1])<<8 | uint64(count[2])<<16 | uint64(count[3])<<24
+		} else if nProofs, err = ReadVarInt(buf); err != nil {
 			return
 		}
-	}
-	r.mlsagSigs = make([]MlsagSig, nMg)
-	for i := 0; i < nMg; i++ {
-		r.mlsagSigs[i].ss = make([][]Key, nMixin+1)
-		for j := 0; j < nMixin+1; j++ {
-			r.mlsagSigs[i].ss[j] = make([]Key, nSS)
-			for k := 0; k < nSS; k++ {
-				if r.mlsagSigs[i].ss[j][k], err = ParseKey(buf); err != nil {
+		if nProofs == 0 || nProofs > uint64(nOutputs) {
+			err = fmt.Errorf("Bad number of range proofs %d for %d outputs", nProofs, nOutputs)
+			return
+		}
+		for i := uint64(0); i < nProofs; i++ {
+			if r.sigType == RCTTypeBulletproofPlus {
+				var proof *BulletproofPlus
+				if proof, err = ParseBulletproofPlus(buf); err != nil {
 					return
 				}
+				r.bulletproofsPlus = append(r.bulletproofsPlus, *proof)
+				continue
+			}
+			var proof *Bulletproof
+			if proof, err = ParseBulletproof(buf); err != nil {
+				return
 			}
+			r.bulletproofs = append(r.bulletproofs, *proof)
 		}
-		if r.mlsagSigs[i].cc, err = ParseKey(buf); err != nil {
-			return
+	}
+
+	if r.usesClsag() {
+		r.clsagSigs = make([]ClsagSig, nInputs)
+		for i := 0; i < nInputs; i++ {
+			var sig *ClsagSig
+			if sig, err = ParseClsagSig(buf, nMixin+1); err != nil {
+				return
+			}
+			r.clsagSigs[i] = *sig
+		}
+	} else {
+		// Full has a single MLSAG over all inputs, the others one per input
+		nMg, nSS := nInputs, 2
+		if r.sigType == RCTTypeFull {
+			nMg, nSS = 1, nInputs+1
+		}
+		r.mlsagSigs = make([]MlsagSig, nMg)
+		for i := 0; i < nMg; i++ {
+			r.mlsagSigs[i].ss = make([][]Key, nMixin+1)
+			for j := 0; j < nMixin+1; j++ {
+				r.mlsagSigs[i].ss[j] = make([]Key, nSS)
+				for k := 0; k < nSS; k++ {
+					if r.mlsagSigs[i].ss[j][k], err = ParseKey(buf); err != nil {
+						return
+					}
+				}
+			}
+			if r.mlsagSigs[i].cc, err = ParseKey(buf); err != nil {
+				return
+			}
 		}
 	}
+
+	if r.sigType >= RCTTypeBulletproof {
+		r.pseudoOuts = make([]Key, nInputs)
+		for i := 0; i < nInputs; i++ {
+			if r.pseudoOuts[i], err = ParseKey(buf); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+func ParseRingCtSignature(buf io.Reader, nInputs, nOutputs, nMixin int) (result *RctSig, err error) {
+	r, err := ParseRctSigBase(buf, nInputs, nOutputs)
+	if err != nil {
+		return
+	}
+	if err = r.parsePrunable(buf, nInputs, nOutputs, nMixin); err != nil {
+		return
+	}
 	result = r
 	return
 }
@@ -3,6 +3,7 @@ package moneroutil
 import (
 	"bytes"
 	"encoding/hex"
+	"encoding/json"
 	"testing"
 )
 
@@ -56,3 +57,89 @@ func TestVerifyRctSimple(t *testing.T) {
 		transaction.ExpandTransaction(pubkeys)
 	}
 }
+
+func TestParseRctTypes(t *testing.T) {
+	randomKeys := func(n int) (result []Key) {
+		for i := 0; i < n; i++ {
+			result = append(result, *RandomPubKey())
+		}
+		return
+	}
+	nInputs, nOutputs, nMixin := 2, 3, 10
+	tests := []struct {
+		name    string
+		sigType uint8
+	}{
+		{"bulletproof", RCTTypeBulletproof},
+		{"bulletproof2", RCTTypeBulletproof2},
+		{"clsag", RCTTypeCLSAG},
+		{"bulletproof plus", RCTTypeBulletproofPlus},
+	}
+	for _, test := range tests {
+		r := &RctSig{}
+		r.sigType = test.sigType
+		r.txFee = 123456789
+		for i := 0; i < nOutputs; i++ {
+			ecdh := EcdhTuple{amount: Key{byte(i), 2, 3, 4, 5, 6, 7, 8}}
+			if test.sigType == RCTTypeBulletproof {
+				ecdh = EcdhTuple{mask: *RandomPubKey(), amount: *RandomPubKey()}
+			}
+			r.ecdhInfo = append(r.ecdhInfo, ecdh)
+			r.outPk = append(r.outPk, CtKey{mask: *RandomPubKey()})
+		}
+		r.pseudoOuts = randomKeys(nInputs)
+		if test.sigType == RCTTypeBulletproofPlus {
+			r.bulletproofsPlus = []BulletproofPlus{{a: *RandomPubKey(), d1: *RandomPubKey(), l: randomKeys(8), r: randomKeys(8)}}
+		} else {
+			r.bulletproofs = []Bulletproof{{a: *RandomPubKey(), t: *RandomPubKey(), l: randomKeys(8), r: randomKeys(8)}}
+		}
+		for i := 0; i < nInputs; i++ {
+			if r.usesClsag() {
+				r.clsagSigs = append(r.clsagSigs, ClsagSig{s: randomKeys(nMixin + 1), c1: *RandomPubKey(), d: *RandomPubKey()})
+				continue
+			}
+			mlsag := MlsagSig{cc: *RandomPubKey()}
+			for j := 0; j < nMixin+1; j++ {
+				mlsag.ss = append(mlsag.ss, randomKeys(2))
+			}
+			r.mlsagSigs = append(r.mlsagSigs, mlsag)
+		}
+		serialized := append(r.SerializeBase(), r.SerializePrunable()...)
+		parsed, err := ParseRingCtSignature(bytes.NewReader(serialized), nInputs, nOutputs, nMixin)
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+			continue
+		}
+		got := append(parsed.SerializeBase(), parsed.SerializePrunable()...)
+		if bytes.Compare(serialized, got) != 0 {
+			t.Errorf("%s: want %x, got %x", test.name, serialized, got)
+		}
+		base, err := ParseRctSigBase(bytes.NewReader(serialized), nInputs, nOutputs)
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+			continue
+		}
+		if base.BaseHash() != r.BaseHash() {
+			t.Errorf("%s: base hash: want %x, got %x", test.name, r.BaseHash(), base.BaseHash())
+		}
+		encoded, err := json.Marshal(parsed)
+		if err != nil {
+			t.Errorf("%s: marshal: %s", test.name, err)
+			continue
+		}
+		decoded := new(RctSig)
+		if err = json.Unmarshal(encoded, decoded); err != nil {
+			t.Errorf("%s: unmarshal: %s", test.name, err)
+			continue
+		}
+		if got = append(decoded.SerializeBase(), decoded.SerializePrunable()...); bytes.Compare(serialized, got) != 0 {
+			t.Errorf("%s: json: want %x, got %x", test.name, serialized, got)
+		}
+	}
+
+	for _, sigType := range []byte{7, 0xff} {
+		if _, err := ParseRingCtSignature(bytes.NewReader([]byte{sigType, 0}), 1, 1, 0); err == nil {
+			t.Errorf("type %d: want error", sigType)
+		}
+	}
+}
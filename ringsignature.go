@@ -3,7 +3,6 @@ package moneroutil
 import (
 	"fmt"
 	"io"
-	"math/rand"
 )
 
 type RingSignatureElement struct {
@@ -13,6 +12,23 @@ type RingSignatureElement struct {
 
 type RingSignature []*RingSignatureElement
 
+// clone returns a copy of the signature that shares no keys with it
+func (r RingSignature) clone() (result RingSignature) {
+	for _, element := range r {
+		c, s := *element.c, *element.r
+		result = append(result, &RingSignatureElement{c: &c, r: &s})
+	}
+	return
+}
+
+func (r *RingSignatureElement) C() Key {
+	return *r.c
+}
+
+func (r *RingSignatureElement) R() Key {
+	return *r.r
+}
+
 func (r *RingSignatureElement) Serialize() (result []byte) {
 	result = make([]byte, 2*KeyLength)
 	copy(result[:KeyLength], r.c[:])
@@ -85,21 +101,45 @@ func HashToScalar(data ...[]byte) (result *Key) {
 	return
 }
 
-func CreateSignature(prefixHash *Hash, mixins []Key, privKey *Key) (keyImage Key, pubKeys []Key, sig RingSignature) {
-	point := privKey.PubKey().HashToEC()
-	keyImagePoint := new(ProjectiveGroupElement)
-	GeScalarMult(keyImagePoint, privKey, point)
+// CreateSignature signs prefixHash with privKey hidden among mixins at a
+// uniformly chosen position of the ring. All randomness is read from rand,
+// and an error is returned if it cannot supply enough.
+func CreateSignature(rand io.Reader, prefixHash *Hash, mixins []Key, privKey *SecretKey) (keyImage Key, pubKeys []Key, sig RingSignature, err error) {
+	ringKeys := make([]Key, len(mixins)+1)
+	privIndex, err := randomIndex(rand, len(ringKeys))
+	if err != nil {
+		return
+	}
+	copy(ringKeys, mixins[:privIndex])
+	ringKeys[privIndex] = *privKey.PubKey()
+	copy(ringKeys[privIndex+1:], mixins[privIndex:])
+	if keyImage, sig, err = CreateRingSignature(rand, prefixHash, ringKeys, privIndex, privKey); err != nil {
+		return
+	}
+	pubKeys = ringKeys
+	return
+}
+
+// CreateRingSignature signs prefixHash over a ring whose order is already
+// fixed, such as the ring of a transaction input sorted by global index.
+// The public key of privKey must be at privIndex.
+func CreateRingSignature(rand io.Reader, prefixHash *Hash, pubKeys []Key, privIndex int, privKey *SecretKey) (keyImage Key, sig RingSignature, err error) {
+	if privIndex < 0 || privIndex >= len(pubKeys) || pubKeys[privIndex] != *privKey.PubKey() {
+		err = fmt.Errorf("Secret key does not match ring member %d", privIndex)
+		return
+	}
+	image := privKey.KeyImage()
 	// convert key Image point from Projective to Extended
 	// in order to precompute
-	keyImagePoint.ToBytes(&keyImage)
 	keyImageGe := new(ExtendedGroupElement)
-	keyImageGe.FromBytes(&keyImage)
+	keyImageGe.FromBytes(&image)
 	var keyImagePre [8]CachedGroupElement
 	GePrecompute(&keyImagePre, keyImageGe)
-	k := RandomScalar()
-	pubKeys = make([]Key, len(mixins)+1)
-	privIndex := rand.Intn(len(pubKeys))
-	pubKeys[privIndex] = *privKey.PubKey()
+	k, err := RandomScalarFrom(rand)
+	if err != nil {
+		return
+	}
+	defer wipeKey(k)
 	r := make([]*RingSignatureElement, len(pubKeys))
 	sum := new(Key)
 	toHash := prefixHash[:]
@@ -111,21 +151,22 @@ func CreateSignature(prefixHash *Hash, mixins []Key, privKey *Key) (keyImage Key
 			GeScalarMultBase(tmpE, k)
 			tmpE.ToBytes(&tmpEBytes)
 			toHash = append(toHash, tmpEBytes[:]...)
-			tmpE = privKey.PubKey().HashToEC()
+			tmpE = pubKeys[i].HashToEC()
 			GeScalarMult(tmpP, k, tmpE)
 			tmpP.ToBytes(&tmpPBytes)
 			toHash = append(toHash, tmpPBytes[:]...)
 		} else {
-			if i > privIndex {
-				pubKeys[i] = mixins[i-1]
-			} else {
-				pubKeys[i] = mixins[i]
+			r[i] = new(RingSignatureElement)
+			if r[i].c, err = RandomScalarFrom(rand); err != nil {
+				return
 			}
-			r[i] = &RingSignatureElement{
-				c: RandomScalar(),
-				r: RandomScalar(),
+			if r[i].r, err = RandomScalarFrom(rand); err != nil {
+				return
+			}
+			if !tmpE.FromBytes(&pubKeys[i]) {
+				err = fmt.Errorf("Ring member %d is not a valid point", i)
+				return
 			}
-			tmpE.FromBytes(&pubKeys[i])
 			GeDoubleScalarMultVartime(tmpP, r[i].c, tmpE, r[i].r)
 			tmpP.ToBytes(&tmpPBytes)
 			toHash = append(toHash, tmpPBytes[:]...)
@@ -139,7 +180,8 @@ func CreateSignature(prefixHash *Hash, mixins []Key, privKey *Key) (keyImage Key
 	h := HashToScalar(toHash)
 	r[privIndex] = NewRingSignatureElement()
 	ScSub(r[privIndex].c, h, sum)
-	ScMulSub(r[privIndex].r, r[privIndex].c, privKey, k)
+	ScMulSub(r[privIndex].r, r[privIndex].c, &privKey.key, k)
+	keyImage = image
 	sig = r
 	return
 }
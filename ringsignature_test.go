@@ -1,6 +1,8 @@
 package moneroutil
 
 import (
+	"bytes"
+	"crypto/rand"
 	"encoding/hex"
 	"fmt"
 	"testing"
@@ -1821,7 +1823,11 @@ func TestCreateSignature(t *testing.T) {
 		for j := 0; j < numMixins; j++ {
 			mixins[j] = *RandomPubKey()
 		}
-		keyImage, pubKeys, sig := CreateSignature(&hash, mixins, privKey)
+		keyImage, pubKeys, sig, err := CreateSignature(rand.Reader, &hash, mixins, privKey)
+		if err != nil {
+			t.Errorf("%d: %s", i, err)
+			continue
+		}
 		if !VerifySignature(&hash, &keyImage, pubKeys, sig) {
 			var pubKeyStr string
 			for _, pk := range pubKeys {
@@ -1831,3 +1837,34 @@ func TestCreateSignature(t *testing.T) {
 		}
 	}
 }
+
+func TestCreateSignatureDeterministic(t *testing.T) {
+	hash := HexToHash("d3a5e0b7dd5bfe1e6b0a4c61ffcd5c5263e6e1fba0f3d93d56b0b0ec9af3ac81")
+	privKey, _, err := GenerateKeyPair(newTestReader("key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mixins := make([]Key, 4)
+	for i := range mixins {
+		_, pubKey, _ := GenerateKeyPair(newTestReader(fmt.Sprintf("mixin %d", i)))
+		mixins[i] = *pubKey
+	}
+	keyImage1, pubKeys1, sig1, err := CreateSignature(newTestReader("sig"), &hash, mixins, privKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyImage2, pubKeys2, sig2, err := CreateSignature(newTestReader("sig"), &hash, mixins, privKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keyImage1 != keyImage2 || fmt.Sprint(pubKeys1) != fmt.Sprint(pubKeys2) || bytes.Compare(sig1.Serialize(), sig2.Serialize()) != 0 {
+		t.Errorf("same randomness gave different signatures")
+	}
+	if !VerifySignature(&hash, &keyImage1, pubKeys1, sig1) {
+		t.Errorf("failed on verify")
+	}
+	_, _, _, err = CreateSignature(bytes.NewReader(make([]byte, 100)), &hash, mixins, privKey)
+	if err == nil {
+		t.Errorf("want error on exhausted randomness")
+	}
+}
@@ -0,0 +1,100 @@
+package moneroutil
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+)
+
+const redactedSecretKey = "SecretKey(REDACTED)"
+
+var SecretKeyMarshalError = fmt.Errorf("Secret keys cannot be marshaled")
+
+// noCopy makes go vet's copylocks check report any copy of a struct it is
+// embedded in
+type noCopy struct{}
+
+func (*noCopy) Lock()   {}
+func (*noCopy) Unlock() {}
+
+// SecretKey holds a private scalar. It is only handled through a pointer,
+// prints as redacted, refuses to be marshaled and should be wiped with Wipe
+// once it is no longer needed.
+type SecretKey struct {
+	_   noCopy
+	key Key
+}
+
+// NewSecretKey copies k into a new SecretKey. The caller remains
+// responsible for wiping k.
+func NewSecretKey(k *Key) (result *SecretKey) {
+	result = new(SecretKey)
+	result.key = *k
+	return
+}
+
+// GenerateSecretKey creates a random SecretKey using rand as the randomness
+// source
+func GenerateSecretKey(rand io.Reader) (result *SecretKey, err error) {
+	k, err := RandomScalarFrom(rand)
+	if err != nil {
+		return
+	}
+	result = NewSecretKey(k)
+	wipeKey(k)
+	return
+}
+
+// Wipe overwrites the secret with zeroes
+func (s *SecretKey) Wipe() {
+	wipeKey(&s.key)
+	runtime.KeepAlive(s)
+}
+
+func (s *SecretKey) PubKey() (pubKey *Key) {
+	pubKey = s.key.PubKey()
+	return
+}
+
+// KeyImage computes x*Hp(xG), which is unique to the key and is used to
+// detect double spends without revealing which ring member signed
+func (s *SecretKey) KeyImage() (result Key) {
+	point := s.PubKey().HashToEC()
+	keyImagePoint := new(ProjectiveGroupElement)
+	GeScalarMult(keyImagePoint, &s.key, point)
+	keyImagePoint.ToBytes(&result)
+	return
+}
+
+func (s *SecretKey) String() string {
+	return redactedSecretKey
+}
+
+func (s *SecretKey) GoString() string {
+	return redactedSecretKey
+}
+
+// Format redacts the key for every verb, including %x and %v
+func (s *SecretKey) Format(f fmt.State, verb rune) {
+	io.WriteString(f, redactedSecretKey)
+}
+
+func (s *SecretKey) MarshalJSON() ([]byte, error) {
+	return nil, SecretKeyMarshalError
+}
+
+func (s *SecretKey) MarshalText() ([]byte, error) {
+	return nil, SecretKeyMarshalError
+}
+
+func wipeKey(k *Key) {
+	for i := range k {
+		k[i] = 0
+	}
+}
+
+func wipeKeys(keys []Key) {
+	for i := range keys {
+		wipeKey(&keys[i])
+	}
+}
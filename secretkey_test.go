@@ -0,0 +1,38 @@
+package moneroutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSecretKeyRedacted(t *testing.T) {
+	k := HexToKey("ac10e070c8574ef374bdd1c5dbe9bacfd927f9ae0705cf08018ff865f6092d0f")
+	secret := NewSecretKey(&k)
+	for _, verb := range []string{"%v", "%+v", "%#v", "%s", "%x", "%X", "%d", "%q"} {
+		got := fmt.Sprintf(verb, secret)
+		if got != redactedSecretKey {
+			t.Errorf("%s: want %s, got %s", verb, redactedSecretKey, got)
+		}
+	}
+	wrapped := struct{ Secret *SecretKey }{secret}
+	if got := fmt.Sprintf("%+v", wrapped); strings.Contains(got, "ac10") {
+		t.Errorf("secret leaked in %s", got)
+	}
+	if _, err := json.Marshal(wrapped); err == nil {
+		t.Errorf("want error marshaling secret key")
+	}
+}
+
+func TestSecretKeyWipe(t *testing.T) {
+	k := HexToKey("ac10e070c8574ef374bdd1c5dbe9bacfd927f9ae0705cf08018ff865f6092d0f")
+	secret := NewSecretKey(&k)
+	if *secret.PubKey() != *k.PubKey() {
+		t.Errorf("pubkey: want %x, got %x", *k.PubKey(), *secret.PubKey())
+	}
+	secret.Wipe()
+	if secret.key != Zero {
+		t.Errorf("want wiped key, got %x", secret.key)
+	}
+}
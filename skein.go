@@ -0,0 +1,109 @@
+package moneroutil
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// Skein-512-256, one of the CryptoNight finalizers
+
+const (
+	skeinKeyScheduleParity = 0x1bd11bdaa9fc1a22
+
+	skeinTypeConfig  = 4
+	skeinTypeMessage = 48
+	skeinTypeOutput  = 63
+
+	skeinFlagFirst = 1 << 62
+	skeinFlagFinal = 1 << 63
+)
+
+var skeinRotations = [8][4]uint{
+	{46, 36, 19, 37}, {33, 27, 14, 42}, {17, 49, 36, 39}, {44, 9, 54, 56},
+	{39, 30, 34, 24}, {13, 50, 10, 17}, {25, 29, 39, 43}, {8, 35, 56, 22},
+}
+
+var skeinPermutation = [8]int{2, 1, 4, 7, 6, 5, 0, 3}
+
+// threefish512 encrypts block with key and tweak
+func threefish512(key *[8]uint64, tweak [2]uint64, block *[8]uint64) (result [8]uint64) {
+	var k [9]uint64
+	copy(k[:], key[:])
+	k[8] = skeinKeyScheduleParity
+	for _, w := range key {
+		k[8] ^= w
+	}
+	t := [3]uint64{tweak[0], tweak[1], tweak[0] ^ tweak[1]}
+	x := *block
+	injectKey := func(s int) {
+		for i := range x {
+			x[i] += k[(s+i)%9]
+		}
+		x[5] += t[s%3]
+		x[6] += t[(s+1)%3]
+		x[7] += uint64(s)
+	}
+	for d := 0; d < 72; d++ {
+		if d%4 == 0 {
+			injectKey(d / 4)
+		}
+		for j := 0; j < 4; j++ {
+			x[2*j] += x[2*j+1]
+			x[2*j+1] = bits.RotateLeft64(x[2*j+1], int(skeinRotations[d%8][j])) ^ x[2*j]
+		}
+		var y [8]uint64
+		for i := range y {
+			y[i] = x[skeinPermutation[i]]
+		}
+		x = y
+	}
+	injectKey(18)
+	result = x
+	return
+}
+
+// skeinUbi chains data of the given type into h, processing at least one
+// block
+func skeinUbi(h *[8]uint64, data []byte, blockType uint64) {
+	position := uint64(0)
+	flags := uint64(skeinFlagFirst)
+	for {
+		var block [64]byte
+		n := copy(block[:], data)
+		data = data[n:]
+		position += uint64(n)
+		final := len(data) == 0
+		tweak := [2]uint64{position, blockType<<56 | flags}
+		if final {
+			tweak[1] |= skeinFlagFinal
+		}
+		var m [8]uint64
+		for i := range m {
+			m[i] = binary.LittleEndian.Uint64(block[8*i:])
+		}
+		out := threefish512(h, tweak, &m)
+		for i := range h {
+			h[i] = out[i] ^ m[i]
+		}
+		flags = 0
+		if final {
+			return
+		}
+	}
+}
+
+func skein512256(data []byte) (result Hash) {
+	var h [8]uint64
+	// the configuration block: schema "SHA3", version 1 and output bits
+	config := make([]byte, 32)
+	copy(config, "SHA3")
+	binary.LittleEndian.PutUint16(config[4:], 1)
+	binary.LittleEndian.PutUint64(config[8:], 256)
+	skeinUbi(&h, config, skeinTypeConfig)
+	skeinUbi(&h, data, skeinTypeMessage)
+	skeinUbi(&h, make([]byte, 8), skeinTypeOutput)
+	for i := 0; i < 4; i++ {
+		binary.LittleEndian.PutUint64(result[8*i:], h[i])
+	}
+	return
+}
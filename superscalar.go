@@ -0,0 +1,613 @@
+package moneroutil
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// SuperscalarHash, the random programs RandomX uses to expand its cache
+// into dataset items. Programs are generated by simulating the decoder and
+// execution ports of a modern x86 CPU so that they run in about
+// superscalarLatency cycles.
+
+const (
+	ssISubR = iota
+	ssIXorR
+	ssIAddRs
+	ssIMulR
+	ssIRorC
+	ssIAddC7
+	ssIXorC7
+	ssIAddC8
+	ssIXorC8
+	ssIAddC9
+	ssIXorC9
+	ssIMulhR
+	ssISmulhR
+	ssIMulRcp
+	ssInvalid = -1
+)
+
+const (
+	superscalarLatency      = 170
+	superscalarCycleMapSize = superscalarLatency + 4
+	superscalarMaxSize      = 3*superscalarLatency + 2
+	superscalarLookForward  = 4
+	superscalarMaxThrowaway = 256
+	// r5 cannot be the destination of IADD_RS, which is a lea on x86
+	superscalarNeedsDisplacement = 5
+)
+
+// execution ports
+const (
+	portP0  = 1
+	portP1  = 2
+	portP5  = 4
+	portP01 = portP0 | portP1
+	portP05 = portP0 | portP5
+	portAll = portP0 | portP1 | portP5
+)
+
+// macroOp is an x86 instruction of one or two uops. Moves without uops are
+// eliminated by the register renamer.
+type macroOp struct {
+	latency   int
+	uop1      uint8
+	uop2      uint8
+	dependent bool
+}
+
+var (
+	mopSubRR   = macroOp{latency: 1, uop1: portAll}
+	mopXorRR   = macroOp{latency: 1, uop1: portAll}
+	mopLeaSib  = macroOp{latency: 1, uop1: portP01}
+	mopMulRR   = macroOp{latency: 3, uop1: portP1}
+	mopRorRI   = macroOp{latency: 1, uop1: portP05}
+	mopAddRI   = macroOp{latency: 1, uop1: portAll}
+	mopXorRI   = macroOp{latency: 1, uop1: portAll}
+	mopMovRR   = macroOp{}
+	mopMulR    = macroOp{latency: 4, uop1: portP1, uop2: portP5}
+	mopImulR   = macroOp{latency: 4, uop1: portP1, uop2: portP5}
+	mopMovRI64 = macroOp{latency: 1, uop1: portAll}
+)
+
+type superscalarInfo struct {
+	ops      []macroOp
+	resultOp int
+	dstOp    int
+	srcOp    int
+}
+
+var superscalarInfos = [...]superscalarInfo{
+	ssISubR:   {[]macroOp{mopSubRR}, 0, 0, 0},
+	ssIXorR:   {[]macroOp{mopXorRR}, 0, 0, 0},
+	ssIAddRs:  {[]macroOp{mopLeaSib}, 0, 0, 0},
+	ssIMulR:   {[]macroOp{mopMulRR}, 0, 0, 0},
+	ssIRorC:   {[]macroOp{mopRorRI}, 0, 0, -1},
+	ssIAddC7:  {[]macroOp{mopAddRI}, 0, 0, -1},
+	ssIXorC7:  {[]macroOp{mopXorRI}, 0, 0, -1},
+	ssIAddC8:  {[]macroOp{mopAddRI}, 0, 0, -1},
+	ssIXorC8:  {[]macroOp{mopXorRI}, 0, 0, -1},
+	ssIAddC9:  {[]macroOp{mopAddRI}, 0, 0, -1},
+	ssIXorC9:  {[]macroOp{mopXorRI}, 0, 0, -1},
+	ssIMulhR:  {[]macroOp{mopMovRR, mopMulR, mopMovRR}, 1, 0, 1},
+	ssISmulhR: {[]macroOp{mopMovRR, mopImulR, mopMovRR}, 1, 0, 1},
+	ssIMulRcp: {[]macroOp{mopMovRI64, {latency: 3, uop1: portP1, dependent: true}}, 1, 1, -1},
+}
+
+// decodeBuffer is a configuration of instruction sizes the decoder can
+// process in one cycle
+type decodeBuffer struct {
+	index  int
+	counts []int
+}
+
+var (
+	decodeBuffer484  = &decodeBuffer{0, []int{4, 8, 4}}
+	decodeBuffer7333 = &decodeBuffer{1, []int{7, 3, 3, 3}}
+	decodeBuffer3733 = &decodeBuffer{2, []int{3, 7, 3, 3}}
+	decodeBuffer493  = &decodeBuffer{3, []int{4, 9, 3}}
+	decodeBuffer4444 = &decodeBuffer{4, []int{4, 4, 4, 4}}
+	decodeBuffer3310 = &decodeBuffer{5, []int{3, 3, 10}}
+	decodeBuffers    = []*decodeBuffer{decodeBuffer484, decodeBuffer7333, decodeBuffer3733, decodeBuffer493}
+)
+
+// blake2Generator is the pseudo random source of program generation
+type blake2Generator struct {
+	data  [64]byte
+	index int
+}
+
+func newBlake2Generator(seed []byte, nonce uint32) (result *blake2Generator) {
+	result = &blake2Generator{index: 64}
+	n := len(seed)
+	if n > 60 {
+		n = 60
+	}
+	copy(result.data[:], seed[:n])
+	binary.LittleEndian.PutUint32(result.data[60:], nonce)
+	return
+}
+
+func (g *blake2Generator) checkData(bytesNeeded int) {
+	if g.index+bytesNeeded > len(g.data) {
+		copy(g.data[:], blake2b(g.data[:], 64))
+		g.index = 0
+	}
+}
+
+func (g *blake2Generator) getByte() (result byte) {
+	g.checkData(1)
+	result = g.data[g.index]
+	g.index++
+	return
+}
+
+func (g *blake2Generator) getUint32() (result uint32) {
+	g.checkData(4)
+	result = binary.LittleEndian.Uint32(g.data[g.index:])
+	g.index += 4
+	return
+}
+
+// fetchNextDecodeBuffer picks the decoder configuration for a cycle
+func fetchNextDecodeBuffer(instructionType int, cycle int, mulCount int, gen *blake2Generator) *decodeBuffer {
+	// the full 128 bit multiplications decode to two uops, which leaves
+	// room for only three macro ops
+	if instructionType == ssIMulhR || instructionType == ssISmulhR {
+		return decodeBuffer3310
+	}
+	// keep the multiplication port saturated
+	if mulCount < cycle+1 {
+		return decodeBuffer4444
+	}
+	// the multiplication of IMUL_RCP needs a 4 byte slot
+	if instructionType == ssIMulRcp {
+		if gen.getByte()&1 != 0 {
+			return decodeBuffer484
+		}
+		return decodeBuffer493
+	}
+	return decodeBuffers[gen.getByte()%4]
+}
+
+type superscalarRegister struct {
+	latency     int
+	lastOpGroup int
+	lastOpPar   int32
+}
+
+type superscalarInstruction struct {
+	opcode int
+	dst    int
+	src    int
+	mod    uint8
+	imm32  uint32
+	// reciprocal of imm32 for IMUL_RCP
+	reciprocal uint64
+}
+
+// superscalarCandidate is an instruction being scheduled
+type superscalarCandidate struct {
+	info             *superscalarInfo
+	opcode           int
+	src              int
+	dst              int
+	mod              uint8
+	imm32            uint32
+	opGroup          int
+	opGroupPar       int32
+	canReuse         bool
+	groupParIsSource bool
+}
+
+func (c *superscalarCandidate) size() int {
+	if c.info == nil {
+		return 0
+	}
+	return len(c.info.ops)
+}
+
+func isZeroOrPowerOf2(x uint32) bool {
+	return x&(x-1) == 0
+}
+
+func (c *superscalarCandidate) create(opcode int, gen *blake2Generator) {
+	c.info = &superscalarInfos[opcode]
+	c.opcode = opcode
+	c.src, c.dst = -1, -1
+	c.canReuse, c.groupParIsSource = false, false
+	c.mod, c.imm32 = 0, 0
+	switch opcode {
+	case ssISubR:
+		c.opGroup = ssIAddRs
+		c.groupParIsSource = true
+	case ssIXorR:
+		c.opGroup = ssIXorR
+		c.groupParIsSource = true
+	case ssIAddRs:
+		c.mod = gen.getByte()
+		c.opGroup = ssIAddRs
+		c.groupParIsSource = true
+	case ssIMulR:
+		c.opGroup = ssIMulR
+		c.groupParIsSource = true
+	case ssIRorC:
+		for c.imm32 == 0 {
+			c.imm32 = uint32(gen.getByte() & 63)
+		}
+		c.opGroup = ssIRorC
+		c.opGroupPar = -1
+	case ssIAddC7, ssIAddC8, ssIAddC9:
+		c.imm32 = gen.getUint32()
+		c.opGroup = ssIAddC7
+		c.opGroupPar = -1
+	case ssIXorC7, ssIXorC8, ssIXorC9:
+		c.imm32 = gen.getUint32()
+		c.opGroup = ssIXorC7
+		c.opGroupPar = -1
+	case ssIMulhR, ssISmulhR:
+		c.canReuse = true
+		c.opGroup = opcode
+		c.opGroupPar = int32(gen.getUint32())
+	case ssIMulRcp:
+		c.imm32 = gen.getUint32()
+		for isZeroOrPowerOf2(c.imm32) {
+			c.imm32 = gen.getUint32()
+		}
+		c.opGroup = ssIMulRcp
+		c.opGroupPar = -1
+	}
+}
+
+// createForSlot picks an instruction whose first macro op fits a slot of
+// the decode buffer
+func (c *superscalarCandidate) createForSlot(gen *blake2Generator, slotSize int, fetchType int, isLast bool) {
+	switch slotSize {
+	case 3:
+		// only the last slot can take a full multiplication, which is followed
+		// by 3-3-10
+		if isLast {
+			c.create([]int{ssISubR, ssIXorR, ssIMulhR, ssISmulhR}[gen.getByte()&3], gen)
+		} else {
+			c.create([]int{ssISubR, ssIXorR}[gen.getByte()&1], gen)
+		}
+	case 4:
+		// 4-4-4-4 issues multiplications in its first three slots
+		if fetchType == decodeBuffer4444.index && !isLast {
+			c.create(ssIMulR, gen)
+		} else {
+			c.create([]int{ssIRorC, ssIAddRs}[gen.getByte()&1], gen)
+		}
+	case 7:
+		c.create([]int{ssIXorC7, ssIAddC7}[gen.getByte()&1], gen)
+	case 8:
+		c.create([]int{ssIXorC8, ssIAddC8}[gen.getByte()&1], gen)
+	case 9:
+		c.create([]int{ssIXorC9, ssIAddC9}[gen.getByte()&1], gen)
+	case 10:
+		c.create(ssIMulRcp, gen)
+	}
+}
+
+func selectRegister(available []int, gen *blake2Generator) (result int, ok bool) {
+	if len(available) == 0 {
+		return
+	}
+	index := 0
+	if len(available) > 1 {
+		index = int(gen.getUint32() % uint32(len(available)))
+	}
+	result, ok = available[index], true
+	return
+}
+
+func (c *superscalarCandidate) selectSource(cycle int, registers *[8]superscalarRegister, gen *blake2Generator) bool {
+	var available []int
+	for i := range registers {
+		if registers[i].latency <= cycle {
+			available = append(available, i)
+		}
+	}
+	// r5 cannot be the destination of IADD_RS, so make it the source if it
+	// is one of only two candidates
+	if len(available) == 2 && c.opcode == ssIAddRs &&
+		(available[0] == superscalarNeedsDisplacement || available[1] == superscalarNeedsDisplacement) {
+		c.src = superscalarNeedsDisplacement
+		c.opGroupPar = superscalarNeedsDisplacement
+		return true
+	}
+	src, ok := selectRegister(available, gen)
+	if !ok {
+		return false
+	}
+	c.src = src
+	if c.groupParIsSource {
+		c.opGroupPar = int32(src)
+	}
+	return true
+}
+
+// selectDestination avoids sequences that could be optimized away: the
+// same operation with the same source twice in a row, a register combined
+// with itself, and chained multiplications unless nothing else is possible
+func (c *superscalarCandidate) selectDestination(cycle int, allowChainedMul bool, registers *[8]superscalarRegister, gen *blake2Generator) bool {
+	var available []int
+	for i, r := range registers {
+		if r.latency <= cycle && (c.canReuse || i != c.src) &&
+			(allowChainedMul || c.opGroup != ssIMulR || r.lastOpGroup != ssIMulR) &&
+			(r.lastOpGroup != c.opGroup || r.lastOpPar != c.opGroupPar) &&
+			(c.opcode != ssIAddRs || i != superscalarNeedsDisplacement) {
+			available = append(available, i)
+		}
+	}
+	dst, ok := selectRegister(available, gen)
+	if ok {
+		c.dst = dst
+	}
+	return ok
+}
+
+// scheduleUop finds the first cycle a port for uop is free, trying P5, P0
+// and P1 in that order to leave the multiplier free
+func scheduleUop(commit bool, uop uint8, portBusy *[superscalarCycleMapSize][3]uint8, cycle int) int {
+	for ; cycle < superscalarCycleMapSize; cycle++ {
+		if uop&portP5 != 0 && portBusy[cycle][2] == 0 {
+			if commit {
+				portBusy[cycle][2] = uop
+			}
+			return cycle
+		}
+		if uop&portP0 != 0 && portBusy[cycle][0] == 0 {
+			if commit {
+				portBusy[cycle][0] = uop
+			}
+			return cycle
+		}
+		if uop&portP1 != 0 && portBusy[cycle][1] == 0 {
+			if commit {
+				portBusy[cycle][1] = uop
+			}
+			return cycle
+		}
+	}
+	return -1
+}
+
+func scheduleMop(commit bool, mop macroOp, portBusy *[superscalarCycleMapSize][3]uint8, cycle int, depCycle int) int {
+	if mop.dependent && depCycle > cycle {
+		cycle = depCycle
+	}
+	if mop.uop1 == 0 {
+		return cycle
+	}
+	if mop.uop2 == 0 {
+		return scheduleUop(commit, mop.uop1, portBusy, cycle)
+	}
+	// both uops of a macro op are scheduled in the same cycle
+	for ; cycle < superscalarCycleMapSize; cycle++ {
+		cycle1 := scheduleUop(false, mop.uop1, portBusy, cycle)
+		cycle2 := scheduleUop(false, mop.uop2, portBusy, cycle)
+		if cycle1 >= 0 && cycle1 == cycle2 {
+			if commit {
+				scheduleUop(true, mop.uop1, portBusy, cycle1)
+				scheduleUop(true, mop.uop2, portBusy, cycle2)
+			}
+			return cycle1
+		}
+	}
+	return -1
+}
+
+type superscalarProgram struct {
+	instructions    []superscalarInstruction
+	addressRegister int
+}
+
+// generateSuperscalar decodes instructions until an execution port is
+// saturated for superscalarLatency cycles
+func generateSuperscalar(gen *blake2Generator) (result superscalarProgram) {
+	var portBusy [superscalarCycleMapSize][3]uint8
+	var registers [8]superscalarRegister
+	for i := range registers {
+		registers[i].lastOpGroup = ssInvalid
+		registers[i].lastOpPar = -1
+	}
+	current := superscalarCandidate{opcode: ssInvalid}
+	macroOpIndex := 0
+	cycle := 0
+	depCycle := 0
+	portsSaturated := false
+	mulCount := 0
+	throwAwayCount := 0
+
+	for decodeCycle := 0; decodeCycle < superscalarLatency && !portsSaturated && len(result.instructions) < superscalarMaxSize; decodeCycle++ {
+		buffer := fetchNextDecodeBuffer(current.opcode, decodeCycle, mulCount, gen)
+		bufferIndex := 0
+		for bufferIndex < len(buffer.counts) {
+			topCycle := cycle
+			if macroOpIndex >= current.size() {
+				if portsSaturated || len(result.instructions) >= superscalarMaxSize {
+					break
+				}
+				current.createForSlot(gen, buffer.counts[bufferIndex], buffer.index, len(buffer.counts) == bufferIndex+1)
+				macroOpIndex = 0
+			}
+			mop := current.info.ops[macroOpIndex]
+			scheduleCycle := scheduleMop(false, mop, &portBusy, cycle, depCycle)
+			if scheduleCycle < 0 {
+				portsSaturated = true
+				break
+			}
+
+			// look a few cycles ahead for operands, or throw the
+			// instruction away
+			if macroOpIndex == current.info.srcOp {
+				forward := 0
+				for ; forward < superscalarLookForward && !current.selectSource(scheduleCycle, &registers, gen); forward++ {
+					scheduleCycle++
+					cycle++
+				}
+				if forward == superscalarLookForward {
+					if throwAwayCount < superscalarMaxThrowaway {
+						throwAwayCount++
+						macroOpIndex = current.size()
+						continue
+					}
+					current = superscalarCandidate{opcode: ssInvalid}
+					break
+				}
+			}
+			if macroOpIndex == current.info.dstOp {
+				forward := 0
+				for ; forward < superscalarLookForward && !current.selectDestination(scheduleCycle, throwAwayCount > 0, &registers, gen); forward++ {
+					scheduleCycle++
+					cycle++
+				}
+				if forward == superscalarLookForward {
+					if throwAwayCount < superscalarMaxThrowaway {
+						throwAwayCount++
+						macroOpIndex = current.size()
+						continue
+					}
+					current = superscalarCandidate{opcode: ssInvalid}
+					break
+				}
+			}
+			throwAwayCount = 0
+
+			scheduleCycle = scheduleMop(true, mop, &portBusy, scheduleCycle, scheduleCycle)
+			if scheduleCycle < 0 {
+				portsSaturated = true
+				break
+			}
+			depCycle = scheduleCycle + mop.latency
+			if macroOpIndex == current.info.resultOp {
+				r := &registers[current.dst]
+				r.latency = depCycle
+				r.lastOpGroup = current.opGroup
+				r.lastOpPar = current.opGroupPar
+			}
+			bufferIndex++
+			macroOpIndex++
+			if scheduleCycle >= superscalarLatency {
+				portsSaturated = true
+			}
+			cycle = topCycle
+
+			if macroOpIndex >= current.size() {
+				src := current.src
+				if src < 0 {
+					src = current.dst
+				}
+				inst := superscalarInstruction{
+					opcode: current.opcode,
+					dst:    current.dst,
+					src:    src,
+					mod:    current.mod,
+					imm32:  current.imm32,
+				}
+				switch current.opcode {
+				case ssIMulRcp:
+					inst.reciprocal = randomxReciprocal(uint64(current.imm32))
+					mulCount++
+				case ssIMulR, ssIMulhR, ssISmulhR:
+					mulCount++
+				}
+				result.instructions = append(result.instructions, inst)
+			}
+		}
+		cycle++
+	}
+
+	// the address register is the one an ASIC with unlimited parallelism
+	// would compute last
+	var asicLatencies [8]int
+	for _, inst := range result.instructions {
+		latDst := asicLatencies[inst.dst] + 1
+		latSrc := 0
+		if inst.dst != inst.src {
+			latSrc = asicLatencies[inst.src] + 1
+		}
+		if latSrc > latDst {
+			latDst = latSrc
+		}
+		asicLatencies[inst.dst] = latDst
+	}
+	asicLatencyMax := 0
+	for i, latency := range asicLatencies {
+		if latency > asicLatencyMax {
+			asicLatencyMax = latency
+			result.addressRegister = i
+		}
+	}
+	return
+}
+
+// randomxReciprocal is 2^x / divisor for the largest x that fits in 64
+// bits
+func randomxReciprocal(divisor uint64) (result uint64) {
+	const p2exp63 = uint64(1) << 63
+	quotient, remainder := p2exp63/divisor, p2exp63%divisor
+	shifts := bits.Len64(divisor)
+	for i := 0; i < shifts; i++ {
+		if remainder >= divisor-remainder {
+			quotient = quotient*2 + 1
+			remainder = remainder*2 - divisor
+		} else {
+			quotient *= 2
+			remainder *= 2
+		}
+	}
+	result = quotient
+	return
+}
+
+func signExtend32(x uint32) uint64 {
+	return uint64(int64(int32(x)))
+}
+
+func mulh(a, b uint64) uint64 {
+	hi, _ := bits.Mul64(a, b)
+	return hi
+}
+
+func smulh(a, b uint64) uint64 {
+	hi, _ := bits.Mul64(a, b)
+	// correct the unsigned high half for negative operands
+	if int64(a) < 0 {
+		hi -= b
+	}
+	if int64(b) < 0 {
+		hi -= a
+	}
+	return hi
+}
+
+// executeSuperscalar runs a program on the registers r
+func executeSuperscalar(r *[8]uint64, program *superscalarProgram) {
+	for _, inst := range program.instructions {
+		switch inst.opcode {
+		case ssISubR:
+			r[inst.dst] -= r[inst.src]
+		case ssIXorR:
+			r[inst.dst] ^= r[inst.src]
+		case ssIAddRs:
+			r[inst.dst] += r[inst.src] << ((inst.mod >> 2) % 4)
+		case ssIMulR:
+			r[inst.dst] *= r[inst.src]
+		case ssIRorC:
+			r[inst.dst] = bits.RotateLeft64(r[inst.dst], -int(inst.imm32))
+		case ssIAddC7, ssIAddC8, ssIAddC9:
+			r[inst.dst] += signExtend32(inst.imm32)
+		case ssIXorC7, ssIXorC8, ssIXorC9:
+			r[inst.dst] ^= signExtend32(inst.imm32)
+		case ssIMulhR:
+			r[inst.dst] = mulh(r[inst.dst], r[inst.src])
+		case ssISmulhR:
+			r[inst.dst] = smulh(r[inst.dst], r[inst.src])
+		case ssIMulRcp:
+			r[inst.dst] *= inst.reciprocal
+		}
+	}
+}
@@ -1,36 +1,85 @@
 package moneroutil
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"sort"
 )
 
 const (
-	txInGenMarker    = 0xff
-	txInToKeyMarker  = 2
-	txOutToKeyMarker = 2
+	txInGenMarker          = 0xff
+	txInToScriptMarker     = 0
+	txInToScriptHashMarker = 1
+	txInToKeyMarker        = 2
+
+	txOutToScriptMarker     = 0
+	txOutToScriptHashMarker = 1
+	txOutToKeyMarker        = 2
+	txOutToTaggedKeyMarker  = 3
 )
 
 var UnimplementedError = fmt.Errorf("Unimplemented")
 
-type txInGen struct {
+// TxInGen is the single input of a coinbase transaction, creating new coins
+// at a block height
+type TxInGen struct {
 	height uint64
 }
 
-type txInToKey struct {
+// TxInToKey spends an output through a ring signature. The ring members
+// are stored as key offsets relative to each other, as on the wire.
+type TxInToKey struct {
 	amount     uint64
 	keyOffsets []uint64
 	keyImage   Key
 }
 
+// TxInToScript spends output prevout of transaction prev with a script.
+// Script inputs were defined but never used on the main chain.
+type TxInToScript struct {
+	prev    Hash
+	prevout uint64
+	sigset  []byte
+}
+
+// TxInToScriptHash spends a script hash output by revealing the script
+type TxInToScriptHash struct {
+	prev    Hash
+	prevout uint64
+	script  TxOutToScript
+	sigset  []byte
+}
+
+// TxInSerializer is implemented by every input type. Use a type switch on
+// *TxInGen, *TxInToKey, *TxInToScript or *TxInToScriptHash to read the
+// input.
 type TxInSerializer interface {
 	TxInSerialize() []byte
 	MixinLen() int
 }
 
+// TxOutToScript locks an output with a script over a list of keys
+type TxOutToScript struct {
+	keys   []Key
+	script []byte
+}
+
+// TxOutToScriptHash locks an output with the hash of a TxOutToScript
+type TxOutToScriptHash struct {
+	hash Hash
+}
+
+// TxOut sends an amount to a one-time key. Outputs created since the view
+// tag hard fork are tagged with the first byte of a hash of the derivation.
+// The unused script variants leave key empty and set script or scriptHash.
 type TxOut struct {
-	amount uint64
-	key    Key
+	amount     uint64
+	key        Key
+	tagged     bool
+	viewTag    byte
+	script     *TxOutToScript
+	scriptHash *TxOutToScriptHash
 }
 
 type TransactionPrefix struct {
@@ -46,6 +95,7 @@ type Transaction struct {
 	signatures   []RingSignature
 	rctSignature *RctSig
 	expanded     bool
+	pruned       bool
 }
 
 func (h *Hash) Serialize() (result []byte) {
@@ -59,26 +109,183 @@ func (p *Key) Serialize() (result []byte) {
 }
 
 func (t *TxOut) Serialize() (result []byte) {
+	if t.script != nil {
+		result = append(Uint64ToBytes(t.amount), txOutToScriptMarker)
+		result = append(result, t.script.Serialize()...)
+		return
+	}
+	if t.scriptHash != nil {
+		result = append(Uint64ToBytes(t.amount), txOutToScriptHashMarker)
+		result = append(result, t.scriptHash.hash[:]...)
+		return
+	}
+	if t.tagged {
+		result = append(Uint64ToBytes(t.amount), txOutToTaggedKeyMarker)
+		result = append(result, t.key[:]...)
+		result = append(result, t.viewTag)
+		return
+	}
 	result = append(Uint64ToBytes(t.amount), txOutToKeyMarker)
 	result = append(result, t.key[:]...)
 	return
 }
 
+// NewTxOut creates an output to key without a view tag
+func NewTxOut(amount uint64, key Key) *TxOut {
+	return &TxOut{amount: amount, key: key}
+}
+
+// NewTaggedTxOut creates an output to key with a view tag, as computed by
+// DeriveViewTag
+func NewTaggedTxOut(amount uint64, key Key, viewTag byte) *TxOut {
+	return &TxOut{amount: amount, key: key, tagged: true, viewTag: viewTag}
+}
+
+func (t *TxOut) Amount() uint64 {
+	return t.amount
+}
+
+// Key returns the one-time public key the output is sent to
+func (t *TxOut) Key() Key {
+	return t.key
+}
+
+// Script returns the script the output is locked with, or nil
+func (t *TxOut) Script() *TxOutToScript {
+	return t.script
+}
+
+// ScriptHash returns the script hash the output is locked with, or nil
+func (t *TxOut) ScriptHash() *TxOutToScriptHash {
+	return t.scriptHash
+}
+
+// Keys returns a copy of the keys the script refers to
+func (t *TxOutToScript) Keys() (result []Key) {
+	result = append([]Key(nil), t.keys...)
+	return
+}
+
+// Script returns a copy of the script
+func (t *TxOutToScript) Script() (result []byte) {
+	result = append([]byte(nil), t.script...)
+	return
+}
+
+func (t *TxOutToScript) Serialize() (result []byte) {
+	result = Uint64ToBytes(uint64(len(t.keys)))
+	result = append(result, serializeKeys(t.keys)...)
+	result = append(result, Uint64ToBytes(uint64(len(t.script)))...)
+	result = append(result, t.script...)
+	return
+}
+
+func (t *TxOutToScriptHash) Hash() Hash {
+	return t.hash
+}
+
+// ViewTag returns the view tag and whether the output has one
+func (t *TxOut) ViewTag() (viewTag byte, tagged bool) {
+	viewTag, tagged = t.viewTag, t.tagged
+	return
+}
+
+// MatchesViewTag reports whether the output may belong to the owner of
+// derivation. It is false for about 255 in 256 outputs of others, and
+// always true for untagged outputs, whose key has to be derived instead.
+func (t *TxOut) MatchesViewTag(derivation *Key, outputIndex uint64) bool {
+	return !t.tagged || DeriveViewTag(derivation, outputIndex) == t.viewTag
+}
+
 func (t *TxOut) String() (result string) {
+	if t.script != nil {
+		result = fmt.Sprintf("script: %x, keys: %x", t.script.script, t.script.keys)
+		return
+	}
+	if t.scriptHash != nil {
+		result = fmt.Sprintf("script hash: %x", t.scriptHash.hash)
+		return
+	}
+	if t.tagged {
+		result = fmt.Sprintf("key: %x, view tag: %02x", t.key, t.viewTag)
+		return
+	}
 	result = fmt.Sprintf("key: %x", t.key)
 	return
 }
 
-func (t *txInGen) TxInSerialize() (result []byte) {
+func (t *TxInGen) TxInSerialize() (result []byte) {
 	result = append([]byte{txInGenMarker}, Uint64ToBytes(t.height)...)
 	return
 }
 
-func (t *txInGen) MixinLen() int {
+func (t *TxInGen) MixinLen() int {
+	return 0
+}
+
+func (t *TxInGen) Height() uint64 {
+	return t.height
+}
+
+func (t *TxInToScript) TxInSerialize() (result []byte) {
+	result = append([]byte{txInToScriptMarker}, t.prev[:]...)
+	result = append(result, Uint64ToBytes(t.prevout)...)
+	result = append(result, Uint64ToBytes(uint64(len(t.sigset)))...)
+	result = append(result, t.sigset...)
+	return
+}
+
+func (t *TxInToScript) MixinLen() int {
 	return 0
 }
 
-func (t *txInToKey) TxInSerialize() (result []byte) {
+func (t *TxInToScript) Prev() Hash {
+	return t.prev
+}
+
+func (t *TxInToScript) Prevout() uint64 {
+	return t.prevout
+}
+
+// Sigset returns a copy of the signatures satisfying the script
+func (t *TxInToScript) Sigset() (result []byte) {
+	result = append([]byte(nil), t.sigset...)
+	return
+}
+
+func (t *TxInToScriptHash) TxInSerialize() (result []byte) {
+	result = append([]byte{txInToScriptHashMarker}, t.prev[:]...)
+	result = append(result, Uint64ToBytes(t.prevout)...)
+	result = append(result, t.script.Serialize()...)
+	result = append(result, Uint64ToBytes(uint64(len(t.sigset)))...)
+	result = append(result, t.sigset...)
+	return
+}
+
+func (t *TxInToScriptHash) MixinLen() int {
+	return 0
+}
+
+func (t *TxInToScriptHash) Prev() Hash {
+	return t.prev
+}
+
+func (t *TxInToScriptHash) Prevout() uint64 {
+	return t.prevout
+}
+
+// Script returns the script whose hash locks the spent output
+func (t *TxInToScriptHash) Script() *TxOutToScript {
+	return &t.script
+}
+
+// Sigset returns a copy of the signatures satisfying the script
+func (t *TxInToScriptHash) Sigset() (result []byte) {
+	result = append([]byte(nil), t.sigset...)
+	return
+}
+
+func (t *TxInToKey) TxInSerialize() (result []byte) {
 	result = append([]byte{txInToKeyMarker}, Uint64ToBytes(t.amount)...)
 	result = append(result, Uint64ToBytes(uint64(len(t.keyOffsets)))...)
 	for _, keyOffset := range t.keyOffsets {
@@ -88,10 +295,97 @@ func (t *txInToKey) TxInSerialize() (result []byte) {
 	return
 }
 
-func (t *txInToKey) MixinLen() int {
+func (t *TxInToKey) MixinLen() int {
 	return len(t.keyOffsets)
 }
 
+// NewTxInToKey creates an input spending keyImage with a ring made of the
+// outputs of the given amount at globalIndices
+func NewTxInToKey(amount uint64, globalIndices []uint64, keyImage Key) (txIn *TxInToKey, err error) {
+	keyOffsets, err := RelativeOffsets(globalIndices)
+	if err != nil {
+		return
+	}
+	txIn = &TxInToKey{
+		amount:     amount,
+		keyOffsets: keyOffsets,
+		keyImage:   keyImage,
+	}
+	return
+}
+
+func (t *TxInToKey) Amount() uint64 {
+	return t.amount
+}
+
+func (t *TxInToKey) KeyImage() Key {
+	return t.keyImage
+}
+
+// KeyOffsets returns a copy of the relative key offsets
+func (t *TxInToKey) KeyOffsets() (result []uint64) {
+	result = append([]uint64(nil), t.keyOffsets...)
+	return
+}
+
+// GlobalIndices returns the global output indices of the ring members
+func (t *TxInToKey) GlobalIndices() (result []uint64, err error) {
+	result, err = AbsoluteOffsets(t.keyOffsets)
+	return
+}
+
+// Ring fetches the public keys of the ring members in ring order
+func (t *TxInToKey) Ring(resolver OutputResolver) (ring []Key, err error) {
+	globalIndices, err := t.GlobalIndices()
+	if err != nil {
+		return
+	}
+	keys := make([]Key, len(globalIndices))
+	for i, globalIndex := range globalIndices {
+		if keys[i], err = resolver.OutputKey(t.amount, globalIndex); err != nil {
+			return
+		}
+	}
+	ring = keys
+	return
+}
+
+// AbsoluteOffsets converts key offsets, each stored relative to the one
+// before it, to global output indices. It fails if they overflow.
+func AbsoluteOffsets(relative []uint64) (result []uint64, err error) {
+	absolute := make([]uint64, len(relative))
+	var sum uint64
+	for i, offset := range relative {
+		if sum+offset < sum {
+			err = fmt.Errorf("Key offset %d overflows the global index", i)
+			return
+		}
+		sum += offset
+		absolute[i] = sum
+	}
+	result = absolute
+	return
+}
+
+// RelativeOffsets sorts a copy of the global output indices and converts
+// them to the relative key offsets stored on the wire. A ring cannot hold
+// the same output twice.
+func RelativeOffsets(absolute []uint64) (result []uint64, err error) {
+	sorted := append([]uint64(nil), absolute...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i] == sorted[i-1] {
+			err = fmt.Errorf("Output %d appears twice in the ring", sorted[i])
+			return
+		}
+	}
+	for i := len(sorted) - 1; i > 0; i-- {
+		sorted[i] -= sorted[i-1]
+	}
+	result = sorted
+	return
+}
+
 func (t *TransactionPrefix) SerializePrefix() (result []byte) {
 	result = append(Uint64ToBytes(uint64(t.version)), Uint64ToBytes(t.unlockTime)...)
 	result = append(result, Uint64ToBytes(uint64(len(t.vin)))...)
@@ -107,11 +401,38 @@ func (t *TransactionPrefix) SerializePrefix() (result []byte) {
 	return
 }
 
+func (t *TransactionPrefix) Version() uint32 {
+	return t.version
+}
+
+func (t *TransactionPrefix) UnlockTime() uint64 {
+	return t.unlockTime
+}
+
+// Inputs returns a copy of the input list
+func (t *TransactionPrefix) Inputs() (result []TxInSerializer) {
+	result = append([]TxInSerializer(nil), t.vin...)
+	return
+}
+
+// Outputs returns a copy of the output list
+func (t *TransactionPrefix) Outputs() (result []*TxOut) {
+	result = append([]*TxOut(nil), t.vout...)
+	return
+}
+
+// Extra returns a copy of the raw tx_extra field
+func (t *TransactionPrefix) Extra() (result []byte) {
+	result = append([]byte(nil), t.extra...)
+	return
+}
+
 func (t *TransactionPrefix) PrefixHash() (hash Hash) {
 	hash = Keccak256(t.SerializePrefix())
 	return
 }
 
+// OutputSum adds up the output amounts, wrapping if they overflow
 func (t *TransactionPrefix) OutputSum() (sum uint64) {
 	for _, output := range t.vout {
 		sum += output.amount
@@ -119,7 +440,86 @@ func (t *TransactionPrefix) OutputSum() (sum uint64) {
 	return
 }
 
+// OutputSumChecked is OutputSum, failing when the amounts overflow
+func (t *TransactionPrefix) OutputSumChecked() (sum uint64, err error) {
+	for i, output := range t.vout {
+		if sum+output.amount < sum {
+			err = fmt.Errorf("Output %d overflows the sum of amounts", i)
+			return
+		}
+		sum += output.amount
+	}
+	return
+}
+
+// InputSum adds up the amounts of the key inputs, wrapping if they
+// overflow. RingCT inputs have an amount of zero.
+func (t *TransactionPrefix) InputSum() (sum uint64) {
+	for _, txIn := range t.vin {
+		if txInWithKey, ok := txIn.(*TxInToKey); ok {
+			sum += txInWithKey.amount
+		}
+	}
+	return
+}
+
+// InputSumChecked is InputSum, failing when the amounts overflow
+func (t *TransactionPrefix) InputSumChecked() (sum uint64, err error) {
+	for i, txIn := range t.vin {
+		if txInWithKey, ok := txIn.(*TxInToKey); ok {
+			if sum+txInWithKey.amount < sum {
+				err = fmt.Errorf("Input %d overflows the sum of amounts", i)
+				return
+			}
+			sum += txInWithKey.amount
+		}
+	}
+	return
+}
+
+// Signatures returns a deep copy of the ring signatures of a version 1
+// transaction, one per input
+func (t *Transaction) Signatures() (result []RingSignature) {
+	for _, signature := range t.signatures {
+		result = append(result, signature.clone())
+	}
+	return
+}
+
+// RctSignature returns a deep copy of the RingCT data of a version 2
+// transaction, or nil for version 1
+func (t *Transaction) RctSignature() (result *RctSig) {
+	if t.rctSignature == nil {
+		return
+	}
+	result = t.rctSignature.clone()
+	return
+}
+
+// Fee is the difference between inputs and outputs for version 1, and the
+// explicit RingCT fee for version 2. Coinbase transactions pay no fee.
+func (t *Transaction) Fee() (fee uint64) {
+	if t.version == 1 {
+		inputSum, outputSum := t.InputSum(), t.OutputSum()
+		if inputSum > outputSum {
+			fee = inputSum - outputSum
+		}
+		return
+	}
+	if t.rctSignature != nil {
+		fee = t.rctSignature.txFee
+	}
+	return
+}
+
 func (t *Transaction) Serialize() (result []byte) {
+	if t.pruned {
+		result = t.SerializePrefix()
+		if t.version != 1 {
+			result = append(result, t.rctSignature.SerializeBase()...)
+		}
+		return
+	}
 	result = t.SerializePrefix()
 	if t.version == 1 {
 		for i := 0; i < len(t.signatures); i++ {
@@ -153,8 +553,8 @@ func (t *Transaction) ExpandTransaction(outputKeys [][]CtKey) {
 	}
 
 	// fill in the outPk property of the ring signature
-	for i, ctKey := range r.outPk {
-		ctKey.destination = t.vout[i].key
+	for i := range r.outPk {
+		r.outPk[i].destination = t.vout[i].key
 	}
 
 	r.message = Key(t.PrefixHash())
@@ -166,24 +566,62 @@ func (t *Transaction) ExpandTransaction(outputKeys [][]CtKey) {
 				r.mixRing[j][i] = outputKeys[i][j]
 			}
 		}
-		r.mlsagSigs = make([]MlsagSig, 1)
+		if len(r.mlsagSigs) != 1 {
+			r.mlsagSigs = make([]MlsagSig, 1)
+		}
 		r.mlsagSigs[0].ii = make([]Key, len(t.vin))
 		for i, txIn := range t.vin {
-			txInWithKey, _ := txIn.(*txInToKey)
+			txInWithKey, _ := txIn.(*TxInToKey)
 			r.mlsagSigs[0].ii[i] = txInWithKey.keyImage
 		}
-	} else if r.sigType == RCTTypeSimple {
+	} else if !r.usesClsag() {
 		r.mixRing = outputKeys
-		r.mlsagSigs = make([]MlsagSig, len(t.vin))
+		if len(r.mlsagSigs) != len(t.vin) {
+			r.mlsagSigs = make([]MlsagSig, len(t.vin))
+		}
 		for i, txIn := range t.vin {
-			txInWithKey, _ := txIn.(*txInToKey)
+			txInWithKey, _ := txIn.(*TxInToKey)
 			r.mlsagSigs[i].ii = make([]Key, 1)
 			r.mlsagSigs[i].ii[0] = txInWithKey.keyImage
 		}
+	} else {
+		r.mixRing = outputKeys
+		for i, txIn := range t.vin {
+			txInWithKey, _ := txIn.(*TxInToKey)
+			if i < len(r.clsagSigs) {
+				r.clsagSigs[i].ii = txInWithKey.keyImage
+			}
+		}
+	}
+	// a single proof covers all the output commitments divided by 8
+	if len(r.bulletproofs) == 1 {
+		r.bulletproofs[0].v = r.proofCommitments()
+	}
+	if len(r.bulletproofsPlus) == 1 {
+		r.bulletproofsPlus[0].v = r.proofCommitments()
 	}
 	t.expanded = true
 }
 
+// Pruned reports whether the transaction was parsed without its
+// signatures, in which case Serialize returns the pruned blob
+func (t *Transaction) Pruned() bool {
+	return t.pruned
+}
+
+// GetHashChecked is GetHash, failing for the pruned transactions it cannot
+// hash. Coinbase transactions have no signatures to prune.
+func (t *Transaction) GetHashChecked() (result Hash, err error) {
+	if t.pruned && !t.IsCoinbase() {
+		err = fmt.Errorf("Pruned transactions need their prunable hash to be hashed")
+		return
+	}
+	result = t.GetHash()
+	return
+}
+
+// GetHash computes the transaction hash. Pruned transactions need the
+// prunable hash from GetHashFromPrunableHash instead, see GetHashChecked.
 func (t *Transaction) GetHash() (result Hash) {
 	if t.version == 1 {
 		result = Keccak256(t.Serialize())
@@ -199,8 +637,60 @@ func (t *Transaction) GetHash() (result Hash) {
 	return
 }
 
-func ParseTxInGen(buf io.Reader) (txIn *txInGen, err error) {
-	t := new(txInGen)
+// GetHashFromPrunableHash computes the hash of a version 2 transaction
+// from its prefix and RingCT base, and the hash of the prunable part
+// supplied by a pruned node. Version 1 transactions are hashed whole, so
+// their signatures cannot be pruned.
+func (t *Transaction) GetHashFromPrunableHash(prunableHash *Hash) (result Hash, err error) {
+	if t.version == 1 {
+		err = fmt.Errorf("Version 1 transactions cannot be hashed without their signatures")
+		return
+	}
+	prefixHash := t.PrefixHash()
+	rctBaseHash := t.rctSignature.BaseHash()
+	result = Keccak256(prefixHash[:], rctBaseHash[:], prunableHash[:])
+	return
+}
+
+// VerifyV1 checks the ring signature of every input of a version 1
+// transaction against its prefix hash. Ring members are fetched from
+// resolver after converting the relative key offsets to global indices.
+// An error is returned when the transaction is malformed or the ring cannot
+// be resolved, and result reports whether all signatures are valid.
+func (t *Transaction) VerifyV1(resolver OutputResolver) (result bool, err error) {
+	if t.version != 1 {
+		err = fmt.Errorf("Transaction version %d is not 1", t.version)
+		return
+	}
+	if len(t.signatures) != len(t.vin) {
+		err = fmt.Errorf("Have %d signatures for %d inputs", len(t.signatures), len(t.vin))
+		return
+	}
+	prefixHash := t.PrefixHash()
+	for i, txIn := range t.vin {
+		txInWithKey, ok := txIn.(*TxInToKey)
+		if !ok {
+			err = fmt.Errorf("Input %d is not a key input", i)
+			return
+		}
+		if len(t.signatures[i]) != len(txInWithKey.keyOffsets) {
+			err = fmt.Errorf("Input %d has %d signatures for %d ring members", i, len(t.signatures[i]), len(txInWithKey.keyOffsets))
+			return
+		}
+		var pubKeys []Key
+		if pubKeys, err = txInWithKey.Ring(resolver); err != nil {
+			return
+		}
+		if !VerifySignature(&prefixHash, &txInWithKey.keyImage, pubKeys, t.signatures[i]) {
+			return
+		}
+	}
+	result = true
+	return
+}
+
+func ParseTxInGen(buf io.Reader) (txIn *TxInGen, err error) {
+	t := new(TxInGen)
 	t.height, err = ReadVarInt(buf)
 	if err != nil {
 		return
@@ -209,22 +699,22 @@ func ParseTxInGen(buf io.Reader) (txIn *txInGen, err error) {
 	return
 }
 
-func ParseTxInToKey(buf io.Reader) (txIn *txInToKey, err error) {
-	t := new(txInToKey)
+func ParseTxInToKey(buf io.Reader) (txIn *TxInToKey, err error) {
+	t := new(TxInToKey)
 	t.amount, err = ReadVarInt(buf)
 	if err != nil {
 		return
 	}
-	keyOffsetLen, err := ReadVarInt(buf)
+	keyOffsetLen, err := readCount(buf, 1)
 	if err != nil {
 		return
 	}
-	t.keyOffsets = make([]uint64, keyOffsetLen, keyOffsetLen)
-	for i := 0; i < int(keyOffsetLen); i++ {
-		t.keyOffsets[i], err = ReadVarInt(buf)
-		if err != nil {
+	for i := uint64(0); i < keyOffsetLen; i++ {
+		var offset uint64
+		if offset, err = ReadVarInt(buf); err != nil {
 			return
 		}
+		t.keyOffsets = append(t.keyOffsets, offset)
 	}
 	pubKey := make([]byte, KeyLength)
 	n, err := buf.Read(pubKey)
@@ -240,6 +730,81 @@ func ParseTxInToKey(buf io.Reader) (txIn *txInToKey, err error) {
 	return
 }
 
+// readCount reads the varint count of items of at least minSize bytes
+// each. Readers such as bytes.Reader that know how many bytes are left
+// fail early on counts that cannot fit; callers still append the items as
+// they are read, so that no count allocates more than the input holds.
+func readCount(buf io.Reader, minSize uint64) (count uint64, err error) {
+	if count, err = ReadVarInt(buf); err != nil {
+		return
+	}
+	if r, ok := buf.(interface{ Len() int }); ok && count > uint64(r.Len())/minSize {
+		err = fmt.Errorf("Count %d does not fit in the %d bytes left", count, r.Len())
+	}
+	return
+}
+
+// parseBlob reads a varint length followed by that many bytes. The bytes
+// are read as they come, so a bad length cannot allocate more than the
+// input holds.
+func parseBlob(buf io.Reader) (result []byte, err error) {
+	length, err := readCount(buf, 1)
+	if err != nil {
+		return
+	}
+	var b bytes.Buffer
+	n, err := b.ReadFrom(io.LimitReader(buf, int64(length)))
+	if err != nil {
+		return
+	}
+	if n < 0 || uint64(n) != length {
+		err = fmt.Errorf("Blob of %d bytes is truncated", length)
+		return
+	}
+	result = b.Bytes()
+	return
+}
+
+func parseHash(buf io.Reader) (result Hash, err error) {
+	_, err = io.ReadFull(buf, result[:])
+	return
+}
+
+func ParseTxInToScript(buf io.Reader) (txIn *TxInToScript, err error) {
+	t := new(TxInToScript)
+	if t.prev, err = parseHash(buf); err != nil {
+		return
+	}
+	if t.prevout, err = ReadVarInt(buf); err != nil {
+		return
+	}
+	if t.sigset, err = parseBlob(buf); err != nil {
+		return
+	}
+	txIn = t
+	return
+}
+
+func ParseTxInToScriptHash(buf io.Reader) (txIn *TxInToScriptHash, err error) {
+	t := new(TxInToScriptHash)
+	if t.prev, err = parseHash(buf); err != nil {
+		return
+	}
+	if t.prevout, err = ReadVarInt(buf); err != nil {
+		return
+	}
+	var script *TxOutToScript
+	if script, err = ParseTxOutToScript(buf); err != nil {
+		return
+	}
+	t.script = *script
+	if t.sigset, err = parseBlob(buf); err != nil {
+		return
+	}
+	txIn = t
+	return
+}
+
 func ParseTxIn(buf io.Reader) (txIn TxInSerializer, err error) {
 	marker := make([]byte, 1)
 	n, err := buf.Read(marker)
@@ -253,9 +818,35 @@ func ParseTxIn(buf io.Reader) (txIn TxInSerializer, err error) {
 	switch {
 	case marker[0] == txInGenMarker:
 		txIn, err = ParseTxInGen(buf)
+	case marker[0] == txInToScriptMarker:
+		txIn, err = ParseTxInToScript(buf)
+	case marker[0] == txInToScriptHashMarker:
+		txIn, err = ParseTxInToScriptHash(buf)
 	case marker[0] == txInToKeyMarker:
 		txIn, err = ParseTxInToKey(buf)
+	default:
+		err = fmt.Errorf("Unknown TxIn marker %#x", marker[0])
+	}
+	return
+}
+
+func ParseTxOutToScript(buf io.Reader) (result *TxOutToScript, err error) {
+	t := new(TxOutToScript)
+	numKeys, err := readCount(buf, KeyLength)
+	if err != nil {
+		return
+	}
+	for i := uint64(0); i < numKeys; i++ {
+		var key Key
+		if _, err = io.ReadFull(buf, key[:]); err != nil {
+			return
+		}
+		t.keys = append(t.keys, key)
+	}
+	if t.script, err = parseBlob(buf); err != nil {
+		return
 	}
+	result = t
 	return
 }
 
@@ -275,10 +866,25 @@ func ParseTxOut(buf io.Reader) (txOut *TxOut, err error) {
 		return
 	}
 	switch {
+	case marker[0] == txOutToScriptMarker:
+		t.script, err = ParseTxOutToScript(buf)
+	case marker[0] == txOutToScriptHashMarker:
+		t.scriptHash = new(TxOutToScriptHash)
+		t.scriptHash.hash, err = parseHash(buf)
 	case marker[0] == txOutToKeyMarker:
 		t.key, err = ParseKey(buf)
+	case marker[0] == txOutToTaggedKeyMarker:
+		if t.key, err = ParseKey(buf); err != nil {
+			return
+		}
+		viewTag := make([]byte, 1)
+		if _, err = io.ReadFull(buf, viewTag); err != nil {
+			return
+		}
+		t.tagged = true
+		t.viewTag = viewTag[0]
 	default:
-		err = fmt.Errorf("Bad Marker")
+		err = fmt.Errorf("Unknown TxOut marker %#x", marker[0])
 		return
 	}
 	if err != nil {
@@ -289,24 +895,13 @@ func ParseTxOut(buf io.Reader) (txOut *TxOut, err error) {
 }
 
 func ParseExtra(buf io.Reader) (extra []byte, err error) {
-	length, err := ReadVarInt(buf)
-	if err != nil {
-		return
-	}
-	e := make([]byte, int(length))
-	n, err := buf.Read(e)
-	if err != nil {
-		return
-	}
-	if n != int(length) {
-		err = fmt.Errorf("Not enough bytes for extra")
-		return
-	}
-	extra = e
+	extra, err = parseBlob(buf)
 	return
 }
 
-func ParseTransaction(buf io.Reader) (transaction *Transaction, err error) {
+// parseTransactionPrefix reads the prefix of a transaction and returns the
+// ring sizes of its key inputs
+func parseTransactionPrefix(buf io.Reader) (transaction *Transaction, mixinLengths []int, err error) {
 	t := new(Transaction)
 	version, err := ReadVarInt(buf)
 	if err != nil {
@@ -317,37 +912,47 @@ func ParseTransaction(buf io.Reader) (transaction *Transaction, err error) {
 	if err != nil {
 		return
 	}
-	numInputs, err := ReadVarInt(buf)
+	// an input has at least a type and a varint
+	numInputs, err := readCount(buf, 2)
 	if err != nil {
 		return
 	}
-	var mixinLengths []int
-	t.vin = make([]TxInSerializer, int(numInputs), int(numInputs))
-	for i := 0; i < int(numInputs); i++ {
-		t.vin[i], err = ParseTxIn(buf)
-		if err != nil {
+	for i := uint64(0); i < numInputs; i++ {
+		var txIn TxInSerializer
+		if txIn, err = ParseTxIn(buf); err != nil {
 			return
 		}
-		mixinLen := t.vin[i].MixinLen()
+		t.vin = append(t.vin, txIn)
+		mixinLen := txIn.MixinLen()
 		if mixinLen > 0 {
 			mixinLengths = append(mixinLengths, mixinLen)
 		}
 	}
-	numOutputs, err := ReadVarInt(buf)
+	// an output has at least an amount, a type and a varint
+	numOutputs, err := readCount(buf, 3)
 	if err != nil {
 		return
 	}
-	t.vout = make([]*TxOut, int(numOutputs), int(numOutputs))
-	for i := 0; i < int(numOutputs); i++ {
-		t.vout[i], err = ParseTxOut(buf)
-		if err != nil {
+	for i := uint64(0); i < numOutputs; i++ {
+		var txOut *TxOut
+		if txOut, err = ParseTxOut(buf); err != nil {
 			return
 		}
+		t.vout = append(t.vout, txOut)
 	}
 	t.extra, err = ParseExtra(buf)
 	if err != nil {
 		return
 	}
+	transaction = t
+	return
+}
+
+func ParseTransaction(buf io.Reader) (transaction *Transaction, err error) {
+	t, mixinLengths, err := parseTransactionPrefix(buf)
+	if err != nil {
+		return
+	}
 	if t.version == 1 {
 		t.signatures, err = ParseSignatures(mixinLengths, buf)
 		if err != nil {
@@ -358,11 +963,30 @@ func ParseTransaction(buf io.Reader) (transaction *Transaction, err error) {
 		if len(mixinLengths) > 0 {
 			nMixins = mixinLengths[0] - 1
 		}
-		t.rctSignature, err = ParseRingCtSignature(buf, int(numInputs), int(numOutputs), nMixins)
+		t.rctSignature, err = ParseRingCtSignature(buf, len(t.vin), len(t.vout), nMixins)
+		if err != nil {
+			return
+		}
+	}
+	transaction = t
+	return
+}
+
+// ParsePrunedTransaction reads a transaction whose signatures have been
+// pruned, as served by pruned nodes: the prefix, and for version 2 the
+// RingCT base. Use GetHashFromPrunableHash to compute its hash.
+func ParsePrunedTransaction(buf io.Reader) (transaction *Transaction, err error) {
+	t, _, err := parseTransactionPrefix(buf)
+	if err != nil {
+		return
+	}
+	if t.version != 1 {
+		t.rctSignature, err = ParseRctSigBase(buf, len(t.vin), len(t.vout))
 		if err != nil {
 			return
 		}
 	}
+	t.pruned = true
 	transaction = t
 	return
 }
@@ -0,0 +1,119 @@
+package moneroutil
+
+import (
+	"fmt"
+)
+
+// treeHashCount is the number of nodes left after the leaves beyond the
+// largest power of two below count have been hashed in pairs
+func treeHashCount(count int) (result int) {
+	result = 1
+	for result*2 < count {
+		result *= 2
+	}
+	return
+}
+
+// TreeHash computes Monero's merkle root. Leaves beyond the largest power
+// of two below their count are hashed in pairs first, so the tree is not
+// the standard one for counts other than powers of two.
+func TreeHash(hashes []Hash) (result Hash) {
+	switch len(hashes) {
+	case 0:
+		return
+	case 1:
+		result = hashes[0]
+		return
+	case 2:
+		result = Keccak256(hashes[0][:], hashes[1][:])
+		return
+	}
+	cnt := treeHashCount(len(hashes))
+	ints := make([]Hash, cnt)
+	copy(ints, hashes[:2*cnt-len(hashes)])
+	for i, j := 2*cnt-len(hashes), 2*cnt-len(hashes); j < cnt; i, j = i+2, j+1 {
+		ints[j] = Keccak256(hashes[i][:], hashes[i+1][:])
+	}
+	for cnt > 2 {
+		cnt /= 2
+		for i, j := 0, 0; j < cnt; i, j = i+2, j+1 {
+			ints[j] = Keccak256(ints[i][:], ints[i+1][:])
+		}
+	}
+	result = Keccak256(ints[0][:], ints[1][:])
+	return
+}
+
+// TreeBranch returns the sibling hashes on the way from hash up to the
+// root of the tree over hashes, as monerod's tree_branch: branch[0] is the
+// sibling nearest the root and bit d of path, set when the node is the
+// left child, goes with branch[d].
+func TreeBranch(hashes []Hash, hash Hash) (branch []Hash, path uint32, err error) {
+	idx := -1
+	for i := range hashes {
+		if hashes[i] == hash {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		err = fmt.Errorf("Hash %x is not in the tree", hash)
+		return
+	}
+	// step records the sibling of idx if it is one of the pair i, i+1
+	step := func(level []Hash, i, j int) {
+		if idx != i && idx != i+1 {
+			return
+		}
+		sibling, bit := i+1, uint32(1)
+		if idx == i+1 {
+			sibling, bit = i, 0
+		}
+		branch = append([]Hash{level[sibling]}, branch...)
+		path = path<<1 | bit
+		idx = j
+	}
+	switch len(hashes) {
+	case 1:
+		return
+	case 2:
+		step(hashes, 0, 0)
+		return
+	}
+	cnt := treeHashCount(len(hashes))
+	ints := make([]Hash, cnt)
+	copy(ints, hashes[:2*cnt-len(hashes)])
+	for i, j := 2*cnt-len(hashes), 2*cnt-len(hashes); j < cnt; i, j = i+2, j+1 {
+		step(hashes, i, j)
+		ints[j] = Keccak256(hashes[i][:], hashes[i+1][:])
+	}
+	for cnt > 2 {
+		cnt /= 2
+		for i, j := 0, 0; j < cnt; i, j = i+2, j+1 {
+			step(ints, i, j)
+			ints[j] = Keccak256(ints[i][:], ints[i+1][:])
+		}
+	}
+	step(ints, 0, 0)
+	return
+}
+
+// TreeBranchHash computes the root of a tree from a leaf and the branch
+// and path returned by TreeBranch
+func TreeBranchHash(hash Hash, branch []Hash, path uint32) (result Hash) {
+	result = hash
+	for d := len(branch) - 1; d >= 0; d-- {
+		if (path>>uint(d))&1 == 1 {
+			result = Keccak256(result[:], branch[d][:])
+		} else {
+			result = Keccak256(branch[d][:], result[:])
+		}
+	}
+	return
+}
+
+// IsBranchInTree reports whether branch and path prove that hash is a leaf
+// of the tree with the given root
+func IsBranchInTree(hash, root Hash, branch []Hash, path uint32) bool {
+	return len(branch) <= 32 && TreeBranchHash(hash, branch, path) == root
+}
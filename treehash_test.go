@@ -0,0 +1,81 @@
+package moneroutil
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestTreeHash(t *testing.T) {
+	var leaves []Hash
+	for i := 0; i < 9; i++ {
+		leaves = append(leaves, Keccak256([]byte(fmt.Sprintf("leaf %d", i))))
+	}
+	node := func(a, b Hash) Hash {
+		return Keccak256(a[:], b[:])
+	}
+	tests := []struct {
+		name string
+		n    int
+		want Hash
+	}{
+		{"one", 1, leaves[0]},
+		{"two", 2, node(leaves[0], leaves[1])},
+		{"three", 3, node(leaves[0], node(leaves[1], leaves[2]))},
+		{"four", 4, node(node(leaves[0], leaves[1]), node(leaves[2], leaves[3]))},
+		{"five", 5, node(node(leaves[0], leaves[1]), node(leaves[2], node(leaves[3], leaves[4])))},
+		{"six", 6, node(node(leaves[0], leaves[1]), node(node(leaves[2], leaves[3]), node(leaves[4], leaves[5])))},
+	}
+	for _, test := range tests {
+		if got := TreeHash(leaves[:test.n]); test.want != got {
+			t.Errorf("%s: want %x, got %x", test.name, test.want, got)
+		}
+	}
+
+	branches := []struct {
+		name   string
+		n      int
+		leaf   int
+		branch []Hash
+		path   uint32
+	}{
+		{"two, left", 2, 0, []Hash{leaves[1]}, 1},
+		{"two, right", 2, 1, []Hash{leaves[0]}, 0},
+		{"three, first", 3, 0, []Hash{node(leaves[1], leaves[2])}, 1},
+		{"three, second", 3, 1, []Hash{leaves[0], leaves[2]}, 2},
+		{"three, third", 3, 2, []Hash{leaves[0], leaves[1]}, 0},
+		{"four, second", 4, 1, []Hash{node(leaves[2], leaves[3]), leaves[0]}, 1},
+	}
+	for _, test := range branches {
+		branch, path, err := TreeBranch(leaves[:test.n], leaves[test.leaf])
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+			continue
+		}
+		if test.path != path {
+			t.Errorf("%s: want path %d, got %d", test.name, test.path, path)
+		}
+		if fmt.Sprintf("%x", test.branch) != fmt.Sprintf("%x", branch) {
+			t.Errorf("%s: want %x, got %x", test.name, test.branch, branch)
+		}
+	}
+
+	for n := 1; n <= len(leaves); n++ {
+		root := TreeHash(leaves[:n])
+		for i := 0; i < n; i++ {
+			branch, path, err := TreeBranch(leaves[:n], leaves[i])
+			if err != nil {
+				t.Errorf("%d leaves, leaf %d: %s", n, i, err)
+				continue
+			}
+			if !IsBranchInTree(leaves[i], root, branch, path) {
+				t.Errorf("%d leaves, leaf %d: branch does not lead to root", n, i)
+			}
+			if len(branch) > 0 && IsBranchInTree(leaves[i], root, branch, path^1) {
+				t.Errorf("%d leaves, leaf %d: want flipped path to fail", n, i)
+			}
+		}
+	}
+	if _, _, err := TreeBranch(leaves[:3], leaves[5]); err == nil {
+		t.Errorf("want error for hash not in tree")
+	}
+}
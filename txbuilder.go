@@ -0,0 +1,350 @@
+package moneroutil
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+)
+
+const (
+	TxExtraTagPubKey = 0x01
+	TxExtraTagNonce  = 0x02
+)
+
+// RingMember is an output already on the chain that is part of the ring of
+// an input. Commitment is only used by BuildRct; pre-RingCT outputs have the
+// commitment G + amount*H.
+type RingMember struct {
+	GlobalIndex uint64
+	Key         Key
+	Commitment  Key
+}
+
+// TxSource is an output being spent, the decoys hiding it in its ring and
+// the one-time secret key that owns it. Mask opens the commitment of the
+// real output and is only used by BuildRct.
+type TxSource struct {
+	Amount     uint64
+	RealOutput RingMember
+	Mixins     []RingMember
+	Secret     *SecretKey
+	Mask       Key
+}
+
+// TxDestination is an amount to be sent to an address. Amounts are split
+// into denominations, one output each.
+type TxDestination struct {
+	Address *Address
+	Amount  uint64
+}
+
+// TxBuilder creates version 1 (pre-RingCT) transactions with Build and
+// version 2 RingCT transactions with BuildRct. The sources must add up to
+// the destinations plus the fee; change is just another destination.
+type TxBuilder struct {
+	Sources      []TxSource
+	Destinations []TxDestination
+	Fee          uint64
+	UnlockTime   uint64
+	Extra        []byte
+}
+
+// builderInput is a source with its ring in global index order
+type builderInput struct {
+	txIn      *TxInToKey
+	ring      []Key
+	ctRing    []CtKey
+	realIndex int
+	secret    *SecretKey
+	amount    uint64
+	mask      Key
+}
+
+// DecomposeAmount splits an amount into its decimal digits times their
+// power of ten, lowest first. The lowest digits are combined into dust for
+// as long as their sum stays within dustThreshold.
+func DecomposeAmount(amount, dustThreshold uint64) (chunks []uint64, dust uint64) {
+	for order := uint64(1); amount != 0; order *= 10 {
+		chunk := (amount % 10) * order
+		amount /= 10
+		if dust+chunk <= dustThreshold {
+			dust += chunk
+			continue
+		}
+		if chunk != 0 {
+			chunks = append(chunks, chunk)
+		}
+	}
+	return
+}
+
+// Build creates and signs a version 1 transaction, reading all randomness
+// from rand. The transaction secret key is returned so that payments can be
+// proven.
+func (b *TxBuilder) Build(rand io.Reader) (transaction *Transaction, txSecret *SecretKey, err error) {
+	inputs, err := b.inputs(false)
+	if err != nil {
+		return
+	}
+	secret, err := GenerateSecretKey(rand)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			secret.Wipe()
+		}
+	}()
+	t := new(Transaction)
+	t.version = 1
+	t.unlockTime = b.UnlockTime
+	for _, input := range inputs {
+		t.vin = append(t.vin, input.txIn)
+	}
+	if t.vout, err = destinationOutputs(b.Destinations, secret); err != nil {
+		return
+	}
+	t.extra = b.extra(secret)
+
+	prefixHash := t.PrefixHash()
+	t.signatures = make([]RingSignature, len(inputs))
+	for i, input := range inputs {
+		if _, t.signatures[i], err = CreateRingSignature(rand, &prefixHash, input.ring, input.realIndex, input.secret); err != nil {
+			return
+		}
+	}
+	transaction = t
+	txSecret = secret
+	return
+}
+
+// BuildRct creates and signs a RingCT transaction with view tagged outputs,
+// a Bulletproof+ range proof and CLSAG ring signatures, reading all
+// randomness from rand. There is one output per destination, shuffled so
+// that the change cannot be told apart; RingCT transactions need between 2
+// and 16 of them. The transaction secret key is returned so that payments
+// can be proven.
+func (b *TxBuilder) BuildRct(rand io.Reader) (transaction *Transaction, txSecret *SecretKey, err error) {
+	if len(b.Destinations) < 2 || len(b.Destinations) > BulletproofPlusMaxOutputs {
+		err = fmt.Errorf("Need between 2 and %d destinations, have %d", BulletproofPlusMaxOutputs, len(b.Destinations))
+		return
+	}
+	inputs, err := b.inputs(true)
+	if err != nil {
+		return
+	}
+	secret, err := GenerateSecretKey(rand)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			secret.Wipe()
+		}
+	}()
+	t := new(Transaction)
+	t.version = 2
+	t.unlockTime = b.UnlockTime
+	r := new(RctSig)
+	r.sigType = RCTTypeBulletproofPlus
+	r.txFee = b.Fee
+	t.rctSignature = r
+	for _, input := range inputs {
+		t.vin = append(t.vin, input.txIn)
+		r.mixRing = append(r.mixRing, input.ctRing)
+	}
+
+	destinations, err := shuffleDestinations(rand, b.Destinations)
+	if err != nil {
+		return
+	}
+	amounts := make([]uint64, len(destinations))
+	masks := make([]Key, len(destinations))
+	defer wipeKeys(masks)
+	for i, destination := range destinations {
+		var spendKey, derivation Key
+		if spendKey, derivation, err = destinationKeys(&destination, secret); err != nil {
+			return
+		}
+		outputIndex := uint64(i)
+		txOut := &TxOut{tagged: true, viewTag: DeriveViewTag(&derivation, outputIndex)}
+		if txOut.key, err = DerivePublicKey(&derivation, outputIndex, &spendKey); err != nil {
+			return
+		}
+		amountKey := DerivationToScalar(&derivation, outputIndex)
+		amounts[i] = destination.Amount
+		masks[i] = *CommitmentMask(amountKey)
+		t.vout = append(t.vout, txOut)
+		r.ecdhInfo = append(r.ecdhInfo, NewEcdhTuple(destination.Amount, amountKey))
+		r.outPk = append(r.outPk, CtKey{destination: txOut.key, mask: Commit(destination.Amount, &masks[i])})
+		wipeKey(amountKey)
+	}
+	t.extra = b.extra(secret)
+
+	// the pseudo output masks are random, except for the last one which
+	// makes them add up to the output masks
+	pseudoMasks, err := randomScalars(rand, len(inputs))
+	if err != nil {
+		return
+	}
+	defer wipeKeys(pseudoMasks)
+	last := &pseudoMasks[len(inputs)-1]
+	*last = Zero
+	for i := range masks {
+		ScAdd(last, last, &masks[i])
+	}
+	for i := range pseudoMasks[:len(inputs)-1] {
+		ScSub(last, last, &pseudoMasks[i])
+	}
+	for i, input := range inputs {
+		r.pseudoOuts = append(r.pseudoOuts, Commit(input.amount, &pseudoMasks[i]))
+	}
+
+	var proof *BulletproofPlus
+	if proof, err = ProveBulletproofPlus(rand, amounts, masks); err != nil {
+		return
+	}
+	r.bulletproofsPlus = []BulletproofPlus{*proof}
+
+	r.message = Key(t.PrefixHash())
+	message := r.SignatureHash()
+	for i, input := range inputs {
+		var sig *ClsagSig
+		if sig, err = CreateClsag(rand, &message, input.ctRing, &r.pseudoOuts[i], input.realIndex, input.secret, &input.mask, &pseudoMasks[i]); err != nil {
+			return
+		}
+		r.clsagSigs = append(r.clsagSigs, *sig)
+	}
+	t.expanded = true
+	transaction = t
+	txSecret = secret
+	return
+}
+
+// inputs checks that the sources balance the destinations and the fee, and
+// returns them as inputs sorted by key image, largest first. RingCT inputs
+// hide their amounts.
+func (b *TxBuilder) inputs(rct bool) (inputs []*builderInput, err error) {
+	if len(b.Sources) == 0 || len(b.Destinations) == 0 {
+		err = fmt.Errorf("Need at least one source and one destination")
+		return
+	}
+	var inputSum, outputSum uint64
+	for _, source := range b.Sources {
+		if inputSum+source.Amount < inputSum {
+			err = fmt.Errorf("Sources overflow")
+			return
+		}
+		inputSum += source.Amount
+	}
+	outputSum = b.Fee
+	for _, destination := range b.Destinations {
+		if outputSum+destination.Amount < outputSum {
+			err = fmt.Errorf("Destinations overflow")
+			return
+		}
+		outputSum += destination.Amount
+	}
+	if inputSum != outputSum {
+		err = fmt.Errorf("Sources of %d do not match destinations plus fee of %d", inputSum, outputSum)
+		return
+	}
+
+	result := make([]*builderInput, len(b.Sources))
+	for i := range b.Sources {
+		if result[i], err = newBuilderInput(&b.Sources[i], rct); err != nil {
+			return
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return bytes.Compare(result[i].txIn.keyImage[:], result[j].txIn.keyImage[:]) > 0
+	})
+	inputs = result
+	return
+}
+
+// shuffleDestinations returns the destinations in a random order
+func shuffleDestinations(rand io.Reader, destinations []TxDestination) (result []TxDestination, err error) {
+	shuffled := append([]TxDestination(nil), destinations...)
+	for i := len(shuffled) - 1; i > 0; i-- {
+		var j int
+		if j, err = randomIndex(rand, i+1); err != nil {
+			return
+		}
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	}
+	result = shuffled
+	return
+}
+
+// extra puts the transaction public key in front of the user's extra field
+func (b *TxBuilder) extra(txSecret *SecretKey) (result []byte) {
+	txPubKey := txSecret.PubKey()
+	result = append([]byte{TxExtraTagPubKey}, txPubKey[:]...)
+	result = append(result, b.Extra...)
+	return
+}
+
+func newBuilderInput(source *TxSource, rct bool) (input *builderInput, err error) {
+	if *source.Secret.PubKey() != source.RealOutput.Key {
+		err = fmt.Errorf("Secret key does not own output %d", source.RealOutput.GlobalIndex)
+		return
+	}
+	members := append([]RingMember{source.RealOutput}, source.Mixins...)
+	sort.Slice(members, func(i, j int) bool { return members[i].GlobalIndex < members[j].GlobalIndex })
+	input = &builderInput{secret: source.Secret, amount: source.Amount, mask: source.Mask}
+	globalIndices := make([]uint64, len(members))
+	input.ring = make([]Key, len(members))
+	input.ctRing = make([]CtKey, len(members))
+	for i, member := range members {
+		if member.GlobalIndex == source.RealOutput.GlobalIndex {
+			input.realIndex = i
+		}
+		globalIndices[i] = member.GlobalIndex
+		input.ring[i] = member.Key
+		input.ctRing[i] = CtKey{destination: member.Key, mask: member.Commitment}
+	}
+	amount := source.Amount
+	if rct {
+		amount = 0
+	}
+	input.txIn, err = NewTxInToKey(amount, globalIndices, source.Secret.KeyImage())
+	return
+}
+
+// destinationOutputs creates one output per denomination of every
+// destination, each to a one-time key derived from the transaction secret
+func destinationOutputs(destinations []TxDestination, txSecret *SecretKey) (outputs []*TxOut, err error) {
+	var outputIndex uint64
+	for _, destination := range destinations {
+		var spendKey, derivation Key
+		if spendKey, derivation, err = destinationKeys(&destination, txSecret); err != nil {
+			return
+		}
+		chunks, _ := DecomposeAmount(destination.Amount, 0)
+		for _, chunk := range chunks {
+			txOut := &TxOut{amount: chunk}
+			if txOut.key, err = DerivePublicKey(&derivation, outputIndex, &spendKey); err != nil {
+				return
+			}
+			outputs = append(outputs, txOut)
+			outputIndex++
+		}
+	}
+	return
+}
+
+// destinationKeys returns the spend key of the destination address and its
+// derivation with the transaction secret
+func destinationKeys(destination *TxDestination, txSecret *SecretKey) (spendKey, derivation Key, err error) {
+	if len(destination.Address.spendingKey) != KeyLength || len(destination.Address.viewingKey) != KeyLength {
+		err = fmt.Errorf("Destination address has bad keys")
+		return
+	}
+	var viewKey Key
+	copy(spendKey[:], destination.Address.spendingKey)
+	copy(viewKey[:], destination.Address.viewingKey)
+	derivation, err = GenerateKeyDerivation(&viewKey, txSecret)
+	return
+}
@@ -0,0 +1,354 @@
+package moneroutil
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+)
+
+func TestDecomposeAmount(t *testing.T) {
+	tests := []struct {
+		name          string
+		amount        uint64
+		dustThreshold uint64
+		chunks        []uint64
+		dust          uint64
+	}{
+		{
+			name:   "zero",
+			amount: 0,
+		},
+		{
+			name:   "digits",
+			amount: 1234,
+			chunks: []uint64{4, 30, 200, 1000},
+		},
+		{
+			name:   "zero digits",
+			amount: 10000000000000,
+			chunks: []uint64{10000000000000},
+		},
+		{
+			name:          "dust",
+			amount:        1234,
+			dustThreshold: 50,
+			chunks:        []uint64{200, 1000},
+			dust:          34,
+		},
+	}
+	for _, test := range tests {
+		chunks, dust := DecomposeAmount(test.amount, test.dustThreshold)
+		if fmt.Sprint(test.chunks) != fmt.Sprint(chunks) || test.dust != dust {
+			t.Errorf("%s: want %v %d, got %v %d", test.name, test.chunks, test.dust, chunks, dust)
+		}
+	}
+}
+
+type testWallet struct {
+	spendSecret *SecretKey
+	viewSecret  *SecretKey
+	address     *Address
+}
+
+func newTestWallet(reader *testReader) (wallet *testWallet) {
+	wallet = new(testWallet)
+	var spendPubKey, viewPubKey *Key
+	wallet.spendSecret, spendPubKey, _ = GenerateKeyPair(reader)
+	wallet.viewSecret, viewPubKey, _ = GenerateKeyPair(reader)
+	wallet.address = &Address{
+		network:     MainNetwork,
+		spendingKey: spendPubKey[:],
+		viewingKey:  viewPubKey[:],
+	}
+	return
+}
+
+// ownedOutputs returns the indices of the outputs of t sent to the wallet
+func (w *testWallet) ownedOutputs(t *Transaction) (result []int) {
+	txPubKey := HexToKey(fmt.Sprintf("%x", t.extra[1:33]))
+	derivation, _ := GenerateKeyDerivation(&txPubKey, w.viewSecret)
+	var spendPubKey Key
+	copy(spendPubKey[:], w.address.spendingKey)
+	for i, txOut := range t.vout {
+		outputKey, _ := DerivePublicKey(&derivation, uint64(i), &spendPubKey)
+		if outputKey == txOut.key {
+			result = append(result, i)
+		}
+	}
+	return
+}
+
+func TestTxBuilder(t *testing.T) {
+	reader := newTestReader("tx builder")
+	sender := newTestWallet(reader)
+	recipient := newTestWallet(reader)
+	resolver := make(MemoryOutputResolver)
+	amounts := []uint64{3000000000000, 700000000000}
+	var sources []TxSource
+	for i, amount := range amounts {
+		// the real output is at global index 2*i+1 among 5 outputs
+		source := TxSource{Amount: amount}
+		for j := 0; j < 5; j++ {
+			secret, pubKey, _ := GenerateKeyPair(reader)
+			resolver[amount] = append(resolver[amount], *pubKey)
+			member := RingMember{GlobalIndex: uint64(j), Key: *pubKey}
+			if j == 2*i+1 {
+				source.RealOutput = member
+				source.Secret = secret
+			} else {
+				source.Mixins = append(source.Mixins, member)
+			}
+		}
+		sources = append(sources, source)
+	}
+	builder := &TxBuilder{
+		Sources: sources,
+		Destinations: []TxDestination{
+			{Address: recipient.address, Amount: 2500000000000},
+			{Address: sender.address, Amount: 1190000000000},
+		},
+		Fee:   10000000000,
+		Extra: []byte{0x02, 0x09, 0x01, 1, 2, 3, 4, 5, 6, 7, 8},
+	}
+	transaction, _, err := builder.Build(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid, err := transaction.VerifyV1(resolver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !valid {
+		t.Errorf("built transaction not verified")
+	}
+	if transaction.Fee() != builder.Fee {
+		t.Errorf("fee: want %d, got %d", builder.Fee, transaction.Fee())
+	}
+	wantAmounts := []uint64{500000000000, 2000000000000, 90000000000, 100000000000, 1000000000000}
+	for i, txOut := range transaction.vout {
+		if txOut.amount != wantAmounts[i] {
+			t.Errorf("output %d: want %d, got %d", i, wantAmounts[i], txOut.amount)
+		}
+	}
+	if got := fmt.Sprint(recipient.ownedOutputs(transaction)); got != "[0 1]" {
+		t.Errorf("recipient outputs: want [0 1], got %s", got)
+	}
+	if got := fmt.Sprint(sender.ownedOutputs(transaction)); got != "[2 3 4]" {
+		t.Errorf("sender outputs: want [2 3 4], got %s", got)
+	}
+	for i := 1; i < len(transaction.vin); i++ {
+		prev := transaction.vin[i-1].(*TxInToKey).keyImage
+		cur := transaction.vin[i].(*TxInToKey).keyImage
+		if bytes.Compare(prev[:], cur[:]) <= 0 {
+			t.Errorf("input %d: key images not in descending order", i)
+		}
+	}
+	serialized := transaction.Serialize()
+	parsed, err := ParseTransaction(bytes.NewReader(serialized))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Compare(serialized, parsed.Serialize()) != 0 {
+		t.Errorf("serialized: want %x, got %x", serialized, parsed.Serialize())
+	}
+	if transaction.GetHash() != parsed.GetHash() {
+		t.Errorf("hash: want %x, got %x", transaction.GetHash(), parsed.GetHash())
+	}
+
+	builder.Fee++
+	if _, _, err = builder.Build(reader); err == nil {
+		t.Errorf("want error for unbalanced transaction")
+	}
+}
+
+func TestTxBuilderRct(t *testing.T) {
+	reader := newTestReader("rct tx builder")
+	sender := newTestWallet(reader)
+	recipient := newTestWallet(reader)
+	amounts := []uint64{3000000000000, 700000000000}
+	var sources []TxSource
+	var outputKeys [][]CtKey
+	for i, amount := range amounts {
+		// the real output is at global index 3*i+2 among 16 outputs
+		source := TxSource{Amount: amount, Mask: *RandomScalar()}
+		var ring []CtKey
+		for j := 0; j < 16; j++ {
+			secret, pubKey, _ := GenerateKeyPair(reader)
+			member := RingMember{GlobalIndex: uint64(10 * j), Key: *pubKey, Commitment: *RandomPubKey()}
+			if j == 3*i+2 {
+				member.Commitment = Commit(amount, &source.Mask)
+				source.RealOutput = member
+				source.Secret = secret
+			} else {
+				source.Mixins = append(source.Mixins, member)
+			}
+			ring = append(ring, CtKey{destination: member.Key, mask: member.Commitment})
+		}
+		sources = append(sources, source)
+		outputKeys = append(outputKeys, ring)
+	}
+	builder := &TxBuilder{
+		Sources: sources,
+		Destinations: []TxDestination{
+			{Address: recipient.address, Amount: 2500000000000},
+			{Address: sender.address, Amount: 1170000000000},
+		},
+		Fee: 30000000000,
+	}
+	transaction, txSecret, err := builder.BuildRct(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := transaction.rctSignature
+	if !r.VerifyRctBulletproofPlus() {
+		t.Errorf("built transaction not verified")
+	}
+	if transaction.Fee() != builder.Fee {
+		t.Errorf("fee: want %d, got %d", builder.Fee, transaction.Fee())
+	}
+	if transaction.InputSum() != 0 || transaction.OutputSum() != 0 {
+		t.Errorf("amounts: want hidden, got %d in and %d out", transaction.InputSum(), transaction.OutputSum())
+	}
+	// the outputs are shuffled, so find each destination by its owner
+	for j, wallet := range []*testWallet{recipient, sender} {
+		owned := wallet.ownedOutputs(transaction)
+		if len(owned) != 1 {
+			t.Errorf("destination %d: want one output, got %v", j, owned)
+			continue
+		}
+		i := owned[0]
+		var viewKey Key
+		copy(viewKey[:], wallet.address.viewingKey)
+		derivation, _ := GenerateKeyDerivation(&viewKey, txSecret)
+		if viewTag := DeriveViewTag(&derivation, uint64(i)); viewTag != transaction.vout[i].viewTag {
+			t.Errorf("output %d: want view tag %x, got %x", i, viewTag, transaction.vout[i].viewTag)
+		}
+		amountKey := DerivationToScalar(&derivation, uint64(i))
+		amount := r.ecdhInfo[i].DecodeAmount(amountKey)
+		if amount != builder.Destinations[j].Amount {
+			t.Errorf("output %d: want amount %d, got %d", i, builder.Destinations[j].Amount, amount)
+		}
+		if commitment := Commit(amount, CommitmentMask(amountKey)); commitment != r.outPk[i].mask {
+			t.Errorf("output %d: want commitment %x, got %x", i, commitment, r.outPk[i].mask)
+		}
+	}
+
+	// the rings are in key image order, so expand with the matching rings
+	keyImages := make(map[Key][]CtKey)
+	for i, source := range sources {
+		keyImages[source.Secret.KeyImage()] = outputKeys[i]
+	}
+	var rings [][]CtKey
+	for _, txIn := range transaction.vin {
+		rings = append(rings, keyImages[txIn.(*TxInToKey).keyImage])
+	}
+	transaction.ExpandTransaction(rings)
+	if !r.VerifyRctBulletproofPlus() {
+		t.Errorf("expanded transaction not verified")
+	}
+
+	serialized := transaction.Serialize()
+	parsed, err := ParseTransaction(bytes.NewReader(serialized))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Compare(serialized, parsed.Serialize()) != 0 {
+		t.Errorf("serialized: want %x, got %x", serialized, parsed.Serialize())
+	}
+	if transaction.GetHash() != parsed.GetHash() {
+		t.Errorf("hash: want %x, got %x", transaction.GetHash(), parsed.GetHash())
+	}
+	parsed.ExpandTransaction(rings)
+	if !parsed.rctSignature.VerifyRctBulletproofPlus() {
+		t.Errorf("parsed transaction not verified")
+	}
+
+	again, _, err := builder.BuildRct(newTestReader("rct tx builder again"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	again.rctSignature.txFee++
+	again.ExpandTransaction(rings)
+	if again.rctSignature.VerifyRctBulletproofPlus() {
+		t.Errorf("transaction with changed fee verified")
+	}
+
+	builder.Destinations = builder.Destinations[:1]
+	builder.Destinations[0].Amount += 1170000000000
+	if _, _, err = builder.BuildRct(reader); err == nil {
+		t.Errorf("want error for a single output")
+	}
+}
+
+func TestShuffleDestinations(t *testing.T) {
+	var destinations []TxDestination
+	for i := 0; i < 4; i++ {
+		destinations = append(destinations, TxDestination{Amount: uint64(i)})
+	}
+	// every destination should end up at every position
+	seen := make(map[[2]uint64]bool)
+	reader := newTestReader("shuffle")
+	for i := 0; i < 100; i++ {
+		shuffled, err := shuffleDestinations(reader, destinations)
+		if err != nil {
+			t.Fatal(err)
+		}
+		used := make(map[uint64]bool)
+		for position, destination := range shuffled {
+			seen[[2]uint64{uint64(position), destination.Amount}] = true
+			used[destination.Amount] = true
+		}
+		if len(shuffled) != len(destinations) || len(used) != len(destinations) {
+			t.Errorf("want a permutation, got %v", shuffled)
+		}
+	}
+	if len(seen) != 16 {
+		t.Errorf("want all 16 placements, got %d", len(seen))
+	}
+	if destinations[0].Amount != 0 || destinations[3].Amount != 3 {
+		t.Errorf("destinations were changed in place")
+	}
+}
+
+// TestSignatureHashFromBlob recomputes monerod's get_pre_mlsag_hash of a
+// built transaction from its wire bytes: the prefix hash, the hash of the
+// RingCT base and the hash of the proof keys without their counts
+func TestSignatureHashFromBlob(t *testing.T) {
+	transaction, _ := newValidateTx(t)
+	blob := transaction.Serialize()
+	prefix := transaction.SerializePrefix()
+	nOutputs := len(transaction.vout)
+	buf := bytes.NewReader(blob[len(prefix):])
+	if sigType, _ := buf.ReadByte(); sigType != RCTTypeBulletproofPlus {
+		t.Fatalf("want type %d, got %d", RCTTypeBulletproofPlus, sigType)
+	}
+	if _, err := ReadVarInt(buf); err != nil {
+		t.Fatal(err)
+	}
+	buf.Seek(int64(8*nOutputs+KeyLength*nOutputs), io.SeekCurrent)
+	baseLength := len(blob) - len(prefix) - buf.Len()
+	base := blob[len(prefix) : len(prefix)+baseLength]
+
+	var keys []byte
+	if nProofs, _ := ReadVarInt(buf); nProofs != 1 {
+		t.Fatalf("want 1 proof, got %d", nProofs)
+	}
+	fixed := make([]byte, 6*KeyLength)
+	io.ReadFull(buf, fixed)
+	keys = append(keys, fixed...)
+	for i := 0; i < 2; i++ {
+		count, _ := ReadVarInt(buf)
+		vector := make([]byte, int(count)*KeyLength)
+		io.ReadFull(buf, vector)
+		keys = append(keys, vector...)
+	}
+
+	prefixHash := Keccak256(prefix)
+	baseHash := Keccak256(base)
+	keysHash := Keccak256(keys)
+	want := Key(Keccak256(prefixHash[:], baseHash[:], keysHash[:]))
+	if got := transaction.rctSignature.SignatureHash(); want != got {
+		t.Errorf("want %x, got %x", want, got)
+	}
+}
@@ -0,0 +1,78 @@
+package moneroutil
+
+const (
+	// Unlock times below this are block heights, the others Unix timestamps
+	MaxBlockNumber = 500000000
+
+	// Coinbase outputs unlock this many blocks after the block mining them
+	MinedMoneyUnlockWindow = 60
+
+	// Outputs can only be spent this many blocks after being included
+	DefaultTxSpendableAge = 10
+
+	// How early a locked output may be spent, in blocks or seconds
+	LockedTxAllowedDeltaBlocks    = 1
+	LockedTxAllowedDeltaSecondsV1 = 60
+	LockedTxAllowedDeltaSecondsV2 = 120
+)
+
+// IsCoinbase reports whether the transaction mints coins from a single
+// generating input
+func (t *TransactionPrefix) IsCoinbase() bool {
+	if len(t.vin) != 1 {
+		return false
+	}
+	_, ok := t.vin[0].(*TxInGen)
+	return ok
+}
+
+// UnlockHeight returns the height the outputs unlock at, if the unlock
+// time is a block height
+func (t *TransactionPrefix) UnlockHeight() (height uint64, ok bool) {
+	if t.unlockTime < MaxBlockNumber {
+		height, ok = t.unlockTime, true
+	}
+	return
+}
+
+// UnlockTimestamp returns the Unix time the outputs unlock at, if the
+// unlock time is a timestamp
+func (t *TransactionPrefix) UnlockTimestamp() (timestamp uint64, ok bool) {
+	if t.unlockTime >= MaxBlockNumber {
+		timestamp, ok = t.unlockTime, true
+	}
+	return
+}
+
+// UnlockTimeReached reports whether an unlock time has passed for a chain
+// of chainHeight blocks at Unix time now, under hard fork version. As in
+// monerod, an output may be spent one block early, or 60 seconds early
+// before version 2 and 120 seconds from then on.
+func UnlockTimeReached(unlockTime, chainHeight, now uint64, version uint8) bool {
+	if unlockTime < MaxBlockNumber {
+		// the top block is at chainHeight-1
+		return chainHeight+LockedTxAllowedDeltaBlocks-1 >= unlockTime
+	}
+	if version < 2 {
+		return now+LockedTxAllowedDeltaSecondsV1 >= unlockTime
+	}
+	return now+LockedTxAllowedDeltaSecondsV2 >= unlockTime
+}
+
+// IsSpendable reports whether the outputs of the transaction, included in
+// the block at blockHeight, can be spent on a chain of chainHeight blocks
+// at Unix time now, under hard fork version. Besides the unlock time,
+// outputs must be DefaultTxSpendableAge blocks deep, and coinbase outputs
+// MinedMoneyUnlockWindow blocks deep.
+func (t *TransactionPrefix) IsSpendable(blockHeight, chainHeight, now uint64, version uint8) bool {
+	if !UnlockTimeReached(t.unlockTime, chainHeight, now, version) {
+		return false
+	}
+	if blockHeight+DefaultTxSpendableAge > chainHeight {
+		return false
+	}
+	if t.IsCoinbase() && blockHeight+MinedMoneyUnlockWindow > chainHeight {
+		return false
+	}
+	return true
+}
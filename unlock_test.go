@@ -0,0 +1,56 @@
+package moneroutil
+
+import (
+	"testing"
+)
+
+func TestIsSpendable(t *testing.T) {
+	txIn, _ := NewTxInToKey(0, []uint64{1, 2}, *RandomPubKey())
+	keyInput := []TxInSerializer{txIn}
+	coinbaseInput := []TxInSerializer{&TxInGen{height: 1000}}
+	tests := []struct {
+		name        string
+		vin         []TxInSerializer
+		unlockTime  uint64
+		blockHeight uint64
+		chainHeight uint64
+		now         uint64
+		version     uint8
+		want        bool
+	}{
+		{"unlocked", keyInput, 0, 1000, 1010, 0, 16, true},
+		{"too young", keyInput, 0, 1000, 1009, 0, 16, false},
+		{"height lock reached", keyInput, 2000, 1000, 2000, 0, 16, true},
+		{"height lock one block early", keyInput, 2000, 1000, 1999, 0, 16, false},
+		{"timestamp lock reached", keyInput, 1600000000, 1000, 1010, 1599999880, 16, true},
+		{"timestamp lock not reached", keyInput, 1600000000, 1000, 1010, 1599999879, 16, false},
+		{"timestamp lock too young", keyInput, 1600000000, 1000, 1005, 1600000000, 16, false},
+		{"timestamp lock reached v1", keyInput, 1400000000, 1000, 1010, 1399999940, 1, true},
+		{"timestamp lock not reached v1", keyInput, 1400000000, 1000, 1010, 1399999939, 1, false},
+		{"coinbase mature", coinbaseInput, 1060, 1000, 1060, 0, 16, true},
+		{"coinbase immature", coinbaseInput, 1060, 1000, 1059, 0, 16, false},
+		{"coinbase without lock", coinbaseInput, 0, 1000, 1059, 0, 16, false},
+	}
+	for _, test := range tests {
+		prefix := &TransactionPrefix{vin: test.vin, unlockTime: test.unlockTime}
+		got := prefix.IsSpendable(test.blockHeight, test.chainHeight, test.now, test.version)
+		if test.want != got {
+			t.Errorf("%s: want %t, got %t", test.name, test.want, got)
+		}
+	}
+
+	prefix := &TransactionPrefix{unlockTime: MaxBlockNumber - 1}
+	if height, ok := prefix.UnlockHeight(); !ok || height != MaxBlockNumber-1 {
+		t.Errorf("want unlock height %d, got %d %t", MaxBlockNumber-1, height, ok)
+	}
+	if _, ok := prefix.UnlockTimestamp(); ok {
+		t.Errorf("want no unlock timestamp")
+	}
+	prefix.unlockTime = MaxBlockNumber
+	if timestamp, ok := prefix.UnlockTimestamp(); !ok || timestamp != MaxBlockNumber {
+		t.Errorf("want unlock timestamp %d, got %d %t", MaxBlockNumber, timestamp, ok)
+	}
+	if _, ok := prefix.UnlockHeight(); ok {
+		t.Errorf("want no unlock height")
+	}
+}
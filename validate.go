@@ -0,0 +1,342 @@
+package moneroutil
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+const (
+	TxExtraTagPadding             = 0x00
+	TxExtraTagMergeMining         = 0x03
+	TxExtraTagAdditionalPubKeys   = 0x04
+	TxExtraTagMysteriousMinergate = 0xde
+
+	// Padding in tx_extra is at most this many zero bytes
+	TxExtraPaddingMaxCount = 255
+
+	// Hard fork version from which output keys must be valid points
+	ValidOutputKeyVersion = 4
+)
+
+// extraTagRanks is the order of tx_extra fields in monerod's sort_tx_extra
+var extraTagRanks = map[byte]int{
+	TxExtraTagPubKey:              0,
+	TxExtraTagAdditionalPubKeys:   1,
+	TxExtraTagNonce:               2,
+	TxExtraTagMergeMining:         3,
+	TxExtraTagMysteriousMinergate: 4,
+}
+
+// ViolationKind says which consensus rule a transaction breaks
+type ViolationKind int
+
+const (
+	ViolationVersion ViolationKind = iota + 1
+	ViolationInputCount
+	ViolationOutputCount
+	ViolationInputType
+	ViolationOutputType
+	ViolationAmount
+	ViolationRingSize
+	ViolationRingMember
+	ViolationLockedRingMember
+	ViolationKeyImage
+	ViolationKeyImageOrder
+	ViolationOutputKey
+	ViolationExtra
+	ViolationRctType
+	ViolationFee
+	ViolationUnlockTime
+)
+
+var violationKindNames = map[ViolationKind]string{
+	ViolationVersion:          "version",
+	ViolationInputCount:       "input count",
+	ViolationOutputCount:      "output count",
+	ViolationInputType:        "input type",
+	ViolationOutputType:       "output type",
+	ViolationAmount:           "amount",
+	ViolationRingSize:         "ring size",
+	ViolationRingMember:       "ring member",
+	ViolationLockedRingMember: "locked ring member",
+	ViolationKeyImage:         "key image",
+	ViolationKeyImageOrder:    "key image order",
+	ViolationOutputKey:        "output key",
+	ViolationExtra:            "extra",
+	ViolationRctType:          "RingCT type",
+	ViolationFee:              "fee",
+	ViolationUnlockTime:       "unlock time",
+}
+
+func (k ViolationKind) String() string {
+	if name, ok := violationKindNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("violation %d", int(k))
+}
+
+// Violation is a consensus rule broken by a transaction. Index is the
+// input or output concerned, or -1 for the whole transaction.
+type Violation struct {
+	kind    ViolationKind
+	index   int
+	message string
+}
+
+func (v *Violation) Kind() ViolationKind {
+	return v.kind
+}
+
+func (v *Violation) Index() int {
+	return v.index
+}
+
+func (v *Violation) Error() string {
+	if v.index < 0 {
+		return fmt.Sprintf("%s: %s", v.kind, v.message)
+	}
+	return fmt.Sprintf("%s %d: %s", v.kind, v.index, v.message)
+}
+
+// addViolation records that a transaction breaks a rule
+type addViolation func(kind ViolationKind, index int, format string, args ...interface{})
+
+// OutputSpendableResolver is an OutputResolver that also knows whether
+// outputs on the chain are unlocked, for example with IsSpendable. When
+// ValidateTransaction is given one, every ring member must be spendable.
+type OutputSpendableResolver interface {
+	OutputResolver
+	OutputSpendable(amount, globalIndex uint64) (bool, error)
+}
+
+// ValidateTransaction checks the structure of a transaction against the
+// rules of a hard fork version, without verifying signatures or proofs.
+// Ring members are looked up with resolver unless it is nil. Amounts must
+// be non-zero in version 1 transactions and hidden, that is zero, in
+// version 2 ones. Coinbase transactions are checked for their unlock time
+// and outputs only.
+func ValidateTransaction(tx *Transaction, rules *Rules, resolver OutputResolver) (violations []Violation) {
+	add := func(kind ViolationKind, index int, format string, args ...interface{}) {
+		violations = append(violations, Violation{kind: kind, index: index, message: fmt.Sprintf(format, args...)})
+	}
+
+	coinbase := tx.IsCoinbase()
+	minVersion := rules.MinTransactionVersion
+	if coinbase {
+		minVersion = 1
+	}
+	if tx.version < minVersion || tx.version > rules.MaxTransactionVersion {
+		add(ViolationVersion, -1, "Version %d is not between %d and %d", tx.version, minVersion, rules.MaxTransactionVersion)
+	}
+
+	if coinbase {
+		height := tx.vin[0].(*TxInGen).height
+		if tx.unlockTime != height+MinedMoneyUnlockWindow {
+			add(ViolationUnlockTime, -1, "Coinbase at height %d unlocks at %d, want %d", height, tx.unlockTime, height+MinedMoneyUnlockWindow)
+		}
+		if tx.version != 1 && (tx.rctSignature == nil || tx.rctSignature.sigType != RCTTypeNull) {
+			add(ViolationRctType, -1, "Coinbase must have a null RingCT signature")
+		}
+	} else {
+		validateInputs(tx, rules, resolver, add)
+		if tx.version != 1 && (tx.rctSignature == nil || !rules.AllowsRctType(tx.rctSignature.sigType)) {
+			sigType := -1
+			if tx.rctSignature != nil {
+				sigType = int(tx.rctSignature.sigType)
+			}
+			add(ViolationRctType, -1, "RingCT type %d is not allowed in version %d", sigType, rules.Version)
+		}
+		if rules.BaseFee != 0 {
+			needed := FeeForWeight(tx.Weight(), rules.BaseFee, 1, rules.Version)
+			if rules.PerByteFee {
+				// as monerod, accept fees up to 2% low
+				needed -= needed / 50
+			}
+			if fee := tx.Fee(); fee < needed {
+				add(ViolationFee, -1, "Fee %d is below %d", fee, needed)
+			}
+		}
+	}
+
+	validateOutputs(tx, rules, coinbase, add)
+	if rules.SortedExtra {
+		if err := checkExtraSorted(tx.extra); err != nil {
+			add(ViolationExtra, -1, "%s", err)
+		}
+	}
+	return
+}
+
+func validateInputs(tx *Transaction, rules *Rules, resolver OutputResolver, add addViolation) {
+	if len(tx.vin) == 0 {
+		add(ViolationInputCount, -1, "No inputs")
+	}
+	spendable, _ := resolver.(OutputSpendableResolver)
+	seen := make(map[Key]bool)
+	var lastKeyImage *Key
+	for i, txIn := range tx.vin {
+		in, ok := txIn.(*TxInToKey)
+		if !ok {
+			add(ViolationInputType, i, "Input is not a key input")
+			continue
+		}
+		switch {
+		case tx.version == 1 && in.amount == 0:
+			add(ViolationAmount, i, "Zero amount input")
+		case tx.version != 1 && in.amount != 0:
+			add(ViolationAmount, i, "RingCT input shows amount %d", in.amount)
+		}
+
+		ringSize := len(in.keyOffsets)
+		if ringSize < rules.MinRingSize || rules.MaxRingSize != 0 && ringSize > rules.MaxRingSize {
+			add(ViolationRingSize, i, "Ring size %d is outside %d to %d", ringSize, rules.MinRingSize, rules.MaxRingSize)
+		}
+		for j := 1; j < ringSize; j++ {
+			if in.keyOffsets[j] == 0 {
+				add(ViolationRingMember, i, "Ring member %d appears twice", j)
+			}
+		}
+		globalIndices, err := in.GlobalIndices()
+		if err != nil {
+			add(ViolationRingMember, i, "%s", err)
+		} else if resolver != nil {
+			for _, globalIndex := range globalIndices {
+				if _, err := resolver.OutputKey(in.amount, globalIndex); err != nil {
+					add(ViolationRingMember, i, "%s", err)
+					continue
+				}
+				if spendable == nil {
+					continue
+				}
+				if ok, err := spendable.OutputSpendable(in.amount, globalIndex); err != nil || !ok {
+					add(ViolationLockedRingMember, i, "Output %d is not spendable", globalIndex)
+				}
+			}
+		}
+
+		if !isPrimeOrderPoint(&in.keyImage) {
+			add(ViolationKeyImage, i, "Key image %x is not a point of prime order", in.keyImage)
+		}
+		if seen[in.keyImage] {
+			add(ViolationKeyImage, i, "Key image %x is spent twice", in.keyImage)
+		}
+		seen[in.keyImage] = true
+		// inputs are sorted by key image, largest first
+		if rules.SortedKeyImages && lastKeyImage != nil && bytes.Compare(in.keyImage[:], lastKeyImage[:]) >= 0 {
+			add(ViolationKeyImageOrder, i, "Key image is not below the one before")
+		}
+		lastKeyImage = &in.keyImage
+	}
+}
+
+func validateOutputs(tx *Transaction, rules *Rules, coinbase bool, add addViolation) {
+	minOutputs := rules.MinOutputs
+	if coinbase {
+		minOutputs = 1
+	}
+	if len(tx.vout) < minOutputs || rules.MaxOutputs != 0 && len(tx.vout) > rules.MaxOutputs {
+		add(ViolationOutputCount, -1, "%d outputs is outside %d to %d", len(tx.vout), minOutputs, rules.MaxOutputs)
+	}
+	var outputSum uint64
+	for i, txOut := range tx.vout {
+		if txOut.script != nil || txOut.scriptHash != nil {
+			add(ViolationOutputType, i, "Script outputs are not allowed")
+			continue
+		}
+		switch {
+		case rules.ViewTags == ViewTagsForbidden && txOut.tagged:
+			add(ViolationOutputType, i, "View tags are not allowed in version %d", rules.Version)
+		case rules.ViewTags == ViewTagsRequired && !txOut.tagged:
+			add(ViolationOutputType, i, "View tags are required in version %d", rules.Version)
+		case i > 0 && txOut.tagged != tx.vout[0].tagged:
+			add(ViolationOutputType, i, "Outputs mix tagged and untagged keys")
+		}
+		switch {
+		case (tx.version == 1 || coinbase) && txOut.amount == 0:
+			add(ViolationAmount, i, "Zero amount output")
+		case tx.version != 1 && !coinbase && txOut.amount != 0:
+			add(ViolationAmount, i, "RingCT output shows amount %d", txOut.amount)
+		}
+		if outputSum+txOut.amount < outputSum {
+			add(ViolationAmount, i, "Output amounts overflow")
+		}
+		outputSum += txOut.amount
+		if rules.Version >= ValidOutputKeyVersion && !new(ExtendedGroupElement).FromBytes(&txOut.key) {
+			add(ViolationOutputKey, i, "Key %x is not a point", txOut.key)
+		}
+	}
+}
+
+// isPrimeOrderPoint reports whether k is a point in the subgroup of order L
+func isPrimeOrderPoint(k *Key) bool {
+	var point ExtendedGroupElement
+	if !point.FromBytes(k) {
+		return false
+	}
+	var product ProjectiveGroupElement
+	GeScalarMult(&product, &L, &point)
+	var result Key
+	product.ToBytes(&result)
+	return result == Identity
+}
+
+// checkExtraSorted parses the fields of a tx_extra and checks they are in
+// the order of extraTagRanks, with any padding last
+func checkExtraSorted(extra []byte) (err error) {
+	buf := bytes.NewReader(extra)
+	var lastTag byte
+	lastRank := -1
+	for buf.Len() > 0 {
+		tag, _ := buf.ReadByte()
+		if tag == TxExtraTagPadding {
+			if buf.Len()+1 > TxExtraPaddingMaxCount || !bytes.Equal(extra[len(extra)-buf.Len():], make([]byte, buf.Len())) {
+				err = fmt.Errorf("Bad padding in extra")
+			}
+			return
+		}
+		rank, ok := extraTagRanks[tag]
+		if !ok {
+			err = fmt.Errorf("Unknown extra field %#x", tag)
+			return
+		}
+		if rank < lastRank {
+			err = fmt.Errorf("Extra field %#x follows field %#x", tag, lastTag)
+			return
+		}
+		lastTag, lastRank = tag, rank
+		var length uint64
+		switch tag {
+		case TxExtraTagPubKey:
+			length = KeyLength
+		case TxExtraTagNonce:
+			var size byte
+			if size, err = buf.ReadByte(); err != nil {
+				err = fmt.Errorf("Extra nonce has no size")
+				return
+			}
+			length = uint64(size)
+		case TxExtraTagAdditionalPubKeys:
+			if length, err = ReadVarInt(buf); err != nil {
+				return
+			}
+			if length > uint64(buf.Len())/KeyLength {
+				err = fmt.Errorf("Extra has %d additional keys in %d bytes", length, buf.Len())
+				return
+			}
+			length *= KeyLength
+		case TxExtraTagMergeMining, TxExtraTagMysteriousMinergate:
+			if length, err = ReadVarInt(buf); err != nil {
+				return
+			}
+		}
+		if length > uint64(buf.Len()) {
+			err = fmt.Errorf("Extra field %#x of %d bytes is truncated", tag, length)
+			return
+		}
+		if _, err = buf.Seek(int64(length), io.SeekCurrent); err != nil {
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,366 @@
+package moneroutil
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+// lockedResolver is a MemoryOutputResolver with some outputs still locked
+type lockedResolver struct {
+	MemoryOutputResolver
+	locked map[uint64]bool
+}
+
+func (l *lockedResolver) OutputSpendable(amount, globalIndex uint64) (bool, error) {
+	return !l.locked[globalIndex], nil
+}
+
+// newValidateTx builds a version 16 transaction spending two outputs with
+// rings of 16 members at global indices 0, 10, ..., 150
+func newValidateTx(t *testing.T) (transaction *Transaction, resolver MemoryOutputResolver) {
+	reader := newTestReader("validate")
+	sender := newTestWallet(reader)
+	recipient := newTestWallet(reader)
+	resolver = MemoryOutputResolver{0: make([]Key, 151)}
+	var sources []TxSource
+	for i, amount := range []uint64{3000000000000, 700000000000} {
+		source := TxSource{Amount: amount, Mask: *RandomScalar()}
+		for j := 0; j < 16; j++ {
+			secret, pubKey, _ := GenerateKeyPair(reader)
+			member := RingMember{GlobalIndex: uint64(10 * j), Key: *pubKey, Commitment: *RandomPubKey()}
+			resolver[0][member.GlobalIndex] = *pubKey
+			if j == 3*i+2 {
+				member.Commitment = Commit(amount, &source.Mask)
+				source.RealOutput = member
+				source.Secret = secret
+			} else {
+				source.Mixins = append(source.Mixins, member)
+			}
+		}
+		sources = append(sources, source)
+	}
+	builder := &TxBuilder{
+		Sources: sources,
+		Destinations: []TxDestination{
+			{Address: recipient.address, Amount: 2500000000000},
+			{Address: sender.address, Amount: 1170000000000},
+		},
+		Fee: 30000000000,
+	}
+	transaction, _, err := builder.BuildRct(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+// setMinimumFee sets the fee of tx to the lowest one accepted at baseFee,
+// less below. It is set twice as the size of the fee changes the weight.
+func setMinimumFee(tx *Transaction, baseFee, below uint64) {
+	for i := 0; i < 2; i++ {
+		needed := FeeForWeight(tx.Weight(), baseFee, 1, 16)
+		tx.rctSignature.txFee = needed - needed/50 - below
+	}
+}
+
+func hasViolation(violations []Violation, kind ViolationKind, index int) bool {
+	for _, v := range violations {
+		if v.Kind() == kind && v.Index() == index {
+			return true
+		}
+	}
+	return false
+}
+
+func TestValidateTransaction(t *testing.T) {
+	notAPoint := HexToKey("0200000000000000000000000000000000000000000000000000000000000000")
+	torsion := HexToKey("c7176a703d4dd84fba3c0b760d10670f2a2053fa2c39ccc64ec7fd7792ac037a")
+	tests := []struct {
+		name   string
+		mutate func(tx *Transaction, rules *Rules, resolver *OutputResolver)
+		kind   ViolationKind
+		index  int
+	}{
+		{
+			name:   "valid",
+			mutate: func(tx *Transaction, rules *Rules, resolver *OutputResolver) {},
+		},
+		{
+			name: "enough fee",
+			mutate: func(tx *Transaction, rules *Rules, resolver *OutputResolver) {
+				rules.BaseFee = 20000
+			},
+		},
+		{
+			name: "low fee",
+			mutate: func(tx *Transaction, rules *Rules, resolver *OutputResolver) {
+				rules.BaseFee = 100000000
+			},
+			kind:  ViolationFee,
+			index: -1,
+		},
+		{
+			name: "fee at the threshold",
+			mutate: func(tx *Transaction, rules *Rules, resolver *OutputResolver) {
+				rules.BaseFee = DynamicBaseFee(600000000000, 300000, 300000, 16)
+				setMinimumFee(tx, rules.BaseFee, 0)
+			},
+		},
+		{
+			name: "fee below the threshold",
+			mutate: func(tx *Transaction, rules *Rules, resolver *OutputResolver) {
+				rules.BaseFee = DynamicBaseFee(600000000000, 300000, 300000, 16)
+				setMinimumFee(tx, rules.BaseFee, 1)
+			},
+			kind:  ViolationFee,
+			index: -1,
+		},
+		{
+			name: "version 1",
+			mutate: func(tx *Transaction, rules *Rules, resolver *OutputResolver) {
+				tx.version = 1
+			},
+			kind:  ViolationVersion,
+			index: -1,
+		},
+		{
+			name: "generating input",
+			mutate: func(tx *Transaction, rules *Rules, resolver *OutputResolver) {
+				tx.vin[1] = &TxInGen{height: 1}
+			},
+			kind:  ViolationInputType,
+			index: 1,
+		},
+		{
+			name: "unsorted key images",
+			mutate: func(tx *Transaction, rules *Rules, resolver *OutputResolver) {
+				tx.vin[0], tx.vin[1] = tx.vin[1], tx.vin[0]
+			},
+			kind:  ViolationKeyImageOrder,
+			index: 1,
+		},
+		{
+			name: "duplicate key image",
+			mutate: func(tx *Transaction, rules *Rules, resolver *OutputResolver) {
+				tx.vin[1].(*TxInToKey).keyImage = tx.vin[0].(*TxInToKey).keyImage
+			},
+			kind:  ViolationKeyImage,
+			index: 1,
+		},
+		{
+			name: "key image with torsion",
+			mutate: func(tx *Transaction, rules *Rules, resolver *OutputResolver) {
+				keyImage := &tx.vin[1].(*TxInToKey).keyImage
+				AddKeys(keyImage, keyImage, &torsion)
+			},
+			kind:  ViolationKeyImage,
+			index: 1,
+		},
+		{
+			name: "small ring",
+			mutate: func(tx *Transaction, rules *Rules, resolver *OutputResolver) {
+				in := tx.vin[0].(*TxInToKey)
+				in.keyOffsets = in.keyOffsets[:15]
+			},
+			kind:  ViolationRingSize,
+			index: 0,
+		},
+		{
+			name: "repeated ring member",
+			mutate: func(tx *Transaction, rules *Rules, resolver *OutputResolver) {
+				tx.vin[1].(*TxInToKey).keyOffsets[4] = 0
+			},
+			kind:  ViolationRingMember,
+			index: 1,
+		},
+		{
+			name: "unknown ring member",
+			mutate: func(tx *Transaction, rules *Rules, resolver *OutputResolver) {
+				tx.vin[0].(*TxInToKey).keyOffsets[15] = 1000
+			},
+			kind:  ViolationRingMember,
+			index: 0,
+		},
+		{
+			name: "overflowing ring member",
+			mutate: func(tx *Transaction, rules *Rules, resolver *OutputResolver) {
+				// caught without looking the ring up
+				*resolver = nil
+				tx.vin[1].(*TxInToKey).keyOffsets[15] = 1<<64 - 1
+			},
+			kind:  ViolationRingMember,
+			index: 1,
+		},
+		{
+			name: "locked ring member",
+			mutate: func(tx *Transaction, rules *Rules, resolver *OutputResolver) {
+				*resolver = &lockedResolver{(*resolver).(MemoryOutputResolver), map[uint64]bool{150: true}}
+			},
+			kind:  ViolationLockedRingMember,
+			index: 0,
+		},
+		{
+			name: "visible output amount",
+			mutate: func(tx *Transaction, rules *Rules, resolver *OutputResolver) {
+				tx.vout[1].amount = 5
+			},
+			kind:  ViolationAmount,
+			index: 1,
+		},
+		{
+			name: "bad output key",
+			mutate: func(tx *Transaction, rules *Rules, resolver *OutputResolver) {
+				tx.vout[0].key = notAPoint
+			},
+			kind:  ViolationOutputKey,
+			index: 0,
+		},
+		{
+			name: "bad output key before version 4",
+			mutate: func(tx *Transaction, rules *Rules, resolver *OutputResolver) {
+				rules.Version = 3
+				tx.vout[0].key = notAPoint
+			},
+		},
+		{
+			name: "missing view tag",
+			mutate: func(tx *Transaction, rules *Rules, resolver *OutputResolver) {
+				tx.vout[0].tagged = false
+			},
+			kind:  ViolationOutputType,
+			index: 0,
+		},
+		{
+			name: "one output",
+			mutate: func(tx *Transaction, rules *Rules, resolver *OutputResolver) {
+				tx.vout = tx.vout[:1]
+			},
+			kind:  ViolationOutputCount,
+			index: -1,
+		},
+		{
+			name: "old RingCT type",
+			mutate: func(tx *Transaction, rules *Rules, resolver *OutputResolver) {
+				tx.rctSignature.sigType = RCTTypeCLSAG
+			},
+			kind:  ViolationRctType,
+			index: -1,
+		},
+		{
+			name: "unsorted extra",
+			mutate: func(tx *Transaction, rules *Rules, resolver *OutputResolver) {
+				tx.extra = append([]byte{TxExtraTagNonce, 1, 7}, tx.extra...)
+			},
+		},
+		{
+			name: "unsorted extra by policy",
+			mutate: func(tx *Transaction, rules *Rules, resolver *OutputResolver) {
+				rules.SortedExtra = true
+				tx.extra = append([]byte{TxExtraTagNonce, 1, 7}, tx.extra...)
+			},
+			kind:  ViolationExtra,
+			index: -1,
+		},
+		{
+			name: "extra in sort_tx_extra order",
+			mutate: func(tx *Transaction, rules *Rules, resolver *OutputResolver) {
+				rules.SortedExtra = true
+				tx.extra = append(tx.extra[:1+KeyLength:1+KeyLength], TxExtraTagAdditionalPubKeys, 1)
+				tx.extra = append(tx.extra, torsion[:]...)
+				tx.extra = append(tx.extra, TxExtraTagNonce, 9, 1, 0, 0, 0, 0, 0, 0, 0, 0)
+			},
+		},
+		{
+			name: "nonce before additional keys",
+			mutate: func(tx *Transaction, rules *Rules, resolver *OutputResolver) {
+				rules.SortedExtra = true
+				tx.extra = append(tx.extra[:1+KeyLength:1+KeyLength], TxExtraTagNonce, 9, 1, 0, 0, 0, 0, 0, 0, 0, 0)
+				tx.extra = append(tx.extra, TxExtraTagAdditionalPubKeys, 1)
+				tx.extra = append(tx.extra, torsion[:]...)
+			},
+			kind:  ViolationExtra,
+			index: -1,
+		},
+		{
+			name: "bad padding",
+			mutate: func(tx *Transaction, rules *Rules, resolver *OutputResolver) {
+				rules.SortedExtra = true
+				tx.extra = append(tx.extra, TxExtraTagPadding, 0, 1)
+			},
+			kind:  ViolationExtra,
+			index: -1,
+		},
+	}
+	for _, test := range tests {
+		tx, memoryResolver := newValidateTx(t)
+		rules, _ := RulesForVersion(16)
+		var resolver OutputResolver = memoryResolver
+		test.mutate(tx, rules, &resolver)
+		violations := ValidateTransaction(tx, rules, resolver)
+		if test.kind == 0 {
+			for _, v := range violations {
+				t.Errorf("%s: want no violations, got %s", test.name, &v)
+			}
+			continue
+		}
+		if !hasViolation(violations, test.kind, test.index) {
+			t.Errorf("%s: want %s violation at %d, got %v", test.name, test.kind, test.index, violations)
+		}
+	}
+}
+
+func TestValidateUnsortedExtra(t *testing.T) {
+	// a mainnet transaction with its payment ID before its public key
+	blob, _ := hex.DecodeString(block40646TxHex)
+	transaction, err := ParseTransaction(bytes.NewReader(blob))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules, _ := RulesForVersion(1)
+	if violations := ValidateTransaction(transaction, rules, nil); len(violations) != 0 {
+		t.Errorf("want no violations, got %v", violations)
+	}
+	rules.SortedExtra = true
+	if violations := ValidateTransaction(transaction, rules, nil); !hasViolation(violations, ViolationExtra, -1) {
+		t.Errorf("want an extra violation by policy, got %v", violations)
+	}
+}
+
+func TestValidateCoinbase(t *testing.T) {
+	wallet := newTestWallet(newTestReader("coinbase wallet"))
+	builder := &CoinbaseBuilder{
+		Height:      3000000,
+		Reward:      600000000000,
+		Address:     wallet.address,
+		HFVersion:   16,
+		ExtraNonce:  []byte("pool"),
+		ReserveSize: 8,
+	}
+	transaction, _, err := builder.Build(newTestReader("validate coinbase"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules, _ := RulesForVersion(16)
+	if violations := ValidateTransaction(transaction, rules, nil); len(violations) != 0 {
+		t.Errorf("want no violations, got %v", violations)
+	}
+
+	transaction.unlockTime++
+	transaction.vout[0].amount = 0
+	transaction.rctSignature = nil
+	violations := ValidateTransaction(transaction, rules, nil)
+	want := []string{
+		"unlock time: Coinbase at height 3000000 unlocks at 3000061, want 3000060",
+		"RingCT type: Coinbase must have a null RingCT signature",
+		"amount 0: Zero amount output",
+	}
+	if len(violations) != len(want) {
+		t.Fatalf("want %d violations, got %v", len(want), violations)
+	}
+	for i, v := range violations {
+		if v.Error() != want[i] {
+			t.Errorf("violation %d: want %q, got %q", i, want[i], v.Error())
+		}
+	}
+}